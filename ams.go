@@ -0,0 +1,163 @@
+package bambulabs_api
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tray IDs with a special meaning in the ams_change_filament command and the tray_now report field.
+const (
+	ExternalTrayID = 254 // external spool holder (vt_tray)
+	NoTrayID       = 255 // nothing loaded
+)
+
+// AMS HT units are addressed by IDs starting at 128 and have a single tray.
+const (
+	amsHTFirstID = 128
+	amsHTLastID  = 135
+	traysPerAMS  = 4
+)
+
+// defaultFilamentTemp is used for filament changes when the target tray does not report a nozzle temperature.
+const defaultFilamentTemp = 220
+
+// globalTrayID converts an AMS and tray index into the printer wide tray ID used by tray_now and ams_change_filament.
+func globalTrayID(amsID, trayID int) (int, error) {
+	switch {
+	case amsID >= 0 && amsID < amsHTFirstID/traysPerAMS && trayID >= 0 && trayID < traysPerAMS:
+		return amsID*traysPerAMS + trayID, nil
+	case amsID >= amsHTFirstID && amsID <= amsHTLastID && trayID == 0:
+		return amsID, nil
+	default:
+		return 0, fmt.Errorf("%w: ams %d tray %d", ErrInvalidTray, amsID, trayID)
+	}
+}
+
+// parseTrayID parses tray IDs as reported in tray_now/tray_tar, unset values are treated as [NoTrayID].
+func parseTrayID(s string) int {
+	if id, ok := reportedTrayID(s); ok {
+		return id
+	}
+	return NoTrayID
+}
+
+// reportedTrayID parses a tray ID as reported in tray_now/tray_tar, ok is false if the printer did not report one.
+func reportedTrayID(s string) (int, bool) {
+	id, err := strconv.Atoi(s)
+	return id, err == nil
+}
+
+// TrayAddress identifies a single AMS tray (0-based AMS and tray index, AMS HT units use their ID with tray 0) or the external spool.
+type TrayAddress struct {
+	AMS  int
+	Tray int
+}
+
+// ExternalSpool addresses the external spool holder (vt_tray).
+var ExternalSpool = TrayAddress{AMS: NoTrayID, Tray: ExternalTrayID}
+
+// IsExternal reports whether t addresses the external spool.
+func (t TrayAddress) IsExternal() bool {
+	return t == ExternalSpool
+}
+
+// ID returns the printer wide tray ID as used in tray_now.
+func (t TrayAddress) ID() (int, error) {
+	if t.IsExternal() {
+		return ExternalTrayID, nil
+	}
+	return globalTrayID(t.AMS, t.Tray)
+}
+
+// FilamentSetting describes the filament loaded in a tray, mirroring the tray fields reported by the printer.
+type FilamentSetting struct {
+	Type    string // e.g. "PLA", "PETG"
+	InfoIdx string // filament preset ID, e.g. "GFL99" for Generic PLA
+
+	// Color is a hex color as RRGGBB or RRGGBBAA, a missing alpha channel is treated as opaque.
+	Color string
+
+	NozzleTempMin int
+	NozzleTempMax int
+
+	// CalibrationIndex selects the pressure advance (K-value) calibration profile stored on the printer, -1 selects the default K-value.
+	// A nil CalibrationIndex leaves the current selection untouched.
+	CalibrationIndex *int
+}
+
+// validate checks the setting against the tray fields and the limits of model m, returning the color normalized to RRGGBBAA.
+func (f FilamentSetting) validate(m Model) (string, error) {
+	if f.Type == "" {
+		return "", fmt.Errorf("%w: missing filament type", ErrInvalidFilament)
+	}
+
+	color, err := normalizeColor(f.Color)
+	if err != nil {
+		return "", err
+	}
+
+	if f.NozzleTempMin <= 0 || f.NozzleTempMax < f.NozzleTempMin {
+		return "", fmt.Errorf("%w: nozzle temperature range %d-%d°C", ErrInvalidFilament, f.NozzleTempMin, f.NozzleTempMax)
+	}
+	if limit := models[m].Limits.MaxNozzleTemp; limit > 0 && float64(f.NozzleTempMax) > limit {
+		return "", fmt.Errorf("%w: nozzle temperature %d°C exceeds %.0f°C", ErrInvalidFilament, f.NozzleTempMax, limit)
+	}
+
+	return color, nil
+}
+
+func normalizeColor(s string) (string, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) == 6 {
+		s += "FF"
+	}
+	if _, err := hex.DecodeString(s); err != nil || len(s) != 8 {
+		return "", fmt.Errorf("%w: color %q is not RRGGBB or RRGGBBAA", ErrInvalidFilament, s)
+	}
+	return strings.ToUpper(s), nil
+}
+
+// Drying limits, the AMS HT has a stronger heater than the AMS 2 Pro.
+const (
+	minDryingTemp       = 40
+	maxDryingTemp2Pro   = 65
+	maxDryingTempHT     = 85
+	dryingCoolingTemp   = 45
+	maxDryingDuration   = 24 * time.Hour
+	dryingDurationUnits = time.Hour
+)
+
+// DryingSettings configures a drying cycle started with [Printer.StartDrying].
+type DryingSettings struct {
+	Temperature int           // target temperature in °C
+	Duration    time.Duration // whole hours, 1 to 24
+	Filament    string        // optional filament type shown on the printer, e.g. "PETG"
+}
+
+func (s DryingSettings) validate(t AMSType) error {
+	maxTemp := maxDryingTemp2Pro
+	if t == AMSTypeHT {
+		maxTemp = maxDryingTempHT
+	}
+
+	if s.Temperature < minDryingTemp || s.Temperature > maxTemp {
+		return fmt.Errorf("%w: temperature %d°C outside %d-%d°C for %s", ErrInvalidDryingSettings, s.Temperature, minDryingTemp, maxTemp, t)
+	}
+	if s.Duration < dryingDurationUnits || s.Duration > maxDryingDuration || s.Duration%dryingDurationUnits != 0 {
+		return fmt.Errorf("%w: duration %s is not 1-24 whole hours", ErrInvalidDryingSettings, s.Duration)
+	}
+	return nil
+}
+
+// homeFlagAutoSwitchFilament is the home_flag bit reporting whether backup filament (auto refill) is enabled.
+const homeFlagAutoSwitchFilament = 1 << 10
+
+// AMSSettings are the AMS behaviours configurable from the printer screen and Bambu Studio.
+type AMSSettings struct {
+	StartupRead     bool // read the RFID of all trays on power on
+	ReadOnInsert    bool // read the RFID when a new spool is inserted
+	CalibrateRemain bool // estimate the remaining filament of Bambu spools
+}
@@ -0,0 +1,91 @@
+package bambulabs_api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGlobalTrayID(t *testing.T) {
+	tests := []struct {
+		ams, tray int
+		want      int
+		err       error
+	}{
+		{ams: 0, tray: 0, want: 0},
+		{ams: 1, tray: 3, want: 7},
+		{ams: 3, tray: 3, want: 15},
+		{ams: 128, tray: 0, want: 128},
+		{ams: 0, tray: 4, err: ErrInvalidTray},
+		{ams: 128, tray: 1, err: ErrInvalidTray},
+		{ams: -1, tray: 0, err: ErrInvalidTray},
+	}
+
+	for _, tt := range tests {
+		got, err := globalTrayID(tt.ams, tt.tray)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("globalTrayID(%d, %d) error = %v, want %v", tt.ams, tt.tray, err, tt.err)
+			continue
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("globalTrayID(%d, %d) = %d, want %d", tt.ams, tt.tray, got, tt.want)
+		}
+	}
+}
+
+func TestParseTrayID(t *testing.T) {
+	if got := parseTrayID("254"); got != ExternalTrayID {
+		t.Errorf("parseTrayID(254) = %d, want %d", got, ExternalTrayID)
+	}
+	if got := parseTrayID(""); got != NoTrayID {
+		t.Errorf("parseTrayID(\"\") = %d, want %d", got, NoTrayID)
+	}
+}
+
+func TestFilamentSettingValidate(t *testing.T) {
+	valid := FilamentSetting{Type: "PLA", InfoIdx: "GFL99", Color: "#ff8000", NozzleTempMin: 190, NozzleTempMax: 230}
+
+	color, err := valid.validate(ModelX1C)
+	if err != nil {
+		t.Fatalf("validate() error = %v", err)
+	}
+	if color != "FF8000FF" {
+		t.Errorf("validate() color = %q, want %q", color, "FF8000FF")
+	}
+
+	invalid := []FilamentSetting{
+		{Type: "", Color: "FF8000", NozzleTempMin: 190, NozzleTempMax: 230},
+		{Type: "PLA", Color: "orange", NozzleTempMin: 190, NozzleTempMax: 230},
+		{Type: "PLA", Color: "FF80", NozzleTempMin: 190, NozzleTempMax: 230},
+		{Type: "PLA", Color: "FF8000", NozzleTempMin: 240, NozzleTempMax: 230},
+		{Type: "PC", Color: "FF8000", NozzleTempMin: 260, NozzleTempMax: 320}, // above X1C nozzle limit
+	}
+	for _, f := range invalid {
+		if _, err := f.validate(ModelX1C); !errors.Is(err, ErrInvalidFilament) {
+			t.Errorf("validate(%+v) error = %v, want %v", f, err, ErrInvalidFilament)
+		}
+	}
+}
+
+func TestDryingSettingsValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    DryingSettings
+		ams  AMSType
+		want error
+	}{
+		{name: "2 pro", s: DryingSettings{Temperature: 55, Duration: 8 * time.Hour}, ams: AMSType2Pro},
+		{name: "ht hot", s: DryingSettings{Temperature: 80, Duration: 12 * time.Hour}, ams: AMSTypeHT},
+		{name: "2 pro too hot", s: DryingSettings{Temperature: 80, Duration: 12 * time.Hour}, ams: AMSType2Pro, want: ErrInvalidDryingSettings},
+		{name: "partial hours", s: DryingSettings{Temperature: 55, Duration: 90 * time.Minute}, ams: AMSType2Pro, want: ErrInvalidDryingSettings},
+		{name: "too long", s: DryingSettings{Temperature: 55, Duration: 48 * time.Hour}, ams: AMSTypeHT, want: ErrInvalidDryingSettings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.s.validate(tt.ams); !errors.Is(err, tt.want) {
+				t.Fatalf("validate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -21,7 +21,10 @@ package bambulabs_api
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
 )
 
 // Model represents the printers model number
@@ -49,6 +52,30 @@ const (
 	ModelX2D
 )
 
+var modelNames = map[Model]string{
+	ModelUnknown: "Unknown",
+	ModelA1Mini:  "A1 mini",
+	ModelA1:      "A1",
+	ModelA2L:     "A2L",
+	ModelP1S:     "P1S",
+	ModelP2S:     "P2S",
+	ModelX1E:     "X1E",
+	ModelX1C:     "X1C",
+	ModelH2S:     "H2S",
+	ModelH2D:     "H2D",
+	ModelH2DPro:  "H2D Pro",
+	ModelH2:      "H2",
+	ModelH2C:     "H2C",
+	ModelX2D:     "X2D",
+}
+
+func (m Model) String() string {
+	if name, ok := modelNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Model(%d)", uint(m))
+}
+
 // Client manages connections to one or more Bambu Lab printers.
 //
 // A Client owns the lifetime of all printers added to it. Closing a Client
@@ -64,18 +91,41 @@ type Client struct {
 
 	ctx    context.Context
 	cancel context.CancelFunc
+
+	logger *slog.Logger
+}
+
+// ClientOption configures a [Client] created with [NewClient].
+type ClientOption func(*Client)
+
+// WithLogger sets the logger of every printer added without its own [Config.Logger].
+// The internal logs of the MQTT library are not included, see [SetMQTTLibraryLogger].
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// SetMQTTLibraryLogger routes the internal logs of the MQTT library to l, nil discards them (the default).
+// The library's loggers are process wide, so this affects every client and printer and should be called once at startup.
+func SetMQTTLibraryLogger(l *slog.Logger) {
+	mqtt.SetPahoLogger(l)
 }
 
 // NewClient creates a new printer client using parent as its lifetime context.
 //
 // When parent is canceled or Close is called, the client shuts down and all
 // managed printers are closed.
-func NewClient(parent context.Context) *Client {
+func NewClient(parent context.Context, opts ...ClientOption) *Client {
 	ctx, cancel := context.WithCancel(parent)
-	return &Client{
+	c := &Client{
 		ctx:    ctx,
 		cancel: cancel,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Add connects to a [Printer] described by cfg and adds it to the client's
@@ -95,6 +145,10 @@ func (c *Client) Add(cfg Config) (Printer, error) {
 		return nil, ErrPrinterExists
 	}
 
+	if cfg.Logger == nil {
+		cfg.Logger = c.logger
+	}
+
 	p, err := NewPrinter(c.ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("connect to %s: %w", cfg.SerialNumber, err)
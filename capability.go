@@ -23,6 +23,28 @@ type ModelInfo struct {
 
 	CapableFans   []Fan
 	CapableLights []Light
+
+	Limits Limits
+}
+
+// Volume is a build volume in mm.
+type Volume struct {
+	X, Y, Z float64
+}
+
+// Limits describes the physical limits of a model, zero values mean the limit is unknown and will not be enforced.
+type Limits struct {
+	// BuildVolume is reachable by a single nozzle.
+	BuildVolume Volume
+	// DualNozzleBuildVolume is reachable by both nozzles of a dual nozzle model, it applies to jobs using more than one tool.
+	DualNozzleBuildVolume Volume
+	MaxNozzleTemp         float64
+	MaxBedTemp            float64
+}
+
+func (m ModelInfo) withLimits(l Limits) ModelInfo {
+	m.Limits = l
+	return m
 }
 
 var fullyCapable ModelInfo = ModelInfo{
@@ -48,6 +70,11 @@ var models = map[Model]ModelInfo{
 		CapableLights: []Light{
 			ChamberLight,
 		},
+		Limits: Limits{
+			BuildVolume:   Volume{180, 180, 180},
+			MaxNozzleTemp: 300,
+			MaxBedTemp:    80,
+		},
 	},
 
 	ModelA1: {
@@ -58,6 +85,11 @@ var models = map[Model]ModelInfo{
 		CapableLights: []Light{
 			ChamberLight,
 		},
+		Limits: Limits{
+			BuildVolume:   Volume{256, 256, 256},
+			MaxNozzleTemp: 300,
+			MaxBedTemp:    100,
+		},
 	},
 
 	// GUESSED, UNSURE
@@ -71,23 +103,56 @@ var models = map[Model]ModelInfo{
 		},
 	},
 
-	ModelP1S: fullyCapable,
-
-	ModelP2S: fullyCapable,
-
-	ModelX1C: fullyCapable,
-
-	ModelX1E: fullyCapable,
+	ModelP1S: fullyCapable.withLimits(Limits{
+		BuildVolume:   Volume{256, 256, 256},
+		MaxNozzleTemp: 300,
+		MaxBedTemp:    100,
+	}),
+
+	ModelP2S: fullyCapable.withLimits(Limits{
+		BuildVolume:   Volume{256, 256, 256},
+		MaxNozzleTemp: 300,
+		MaxBedTemp:    110,
+	}),
+
+	ModelX1C: fullyCapable.withLimits(Limits{
+		BuildVolume:   Volume{256, 256, 256},
+		MaxNozzleTemp: 300,
+		MaxBedTemp:    110,
+	}),
+
+	ModelX1E: fullyCapable.withLimits(Limits{
+		BuildVolume:   Volume{256, 256, 256},
+		MaxNozzleTemp: 320,
+		MaxBedTemp:    120,
+	}),
 
 	ModelX2D: fullyCapable,
 
 	ModelH2: fullyCapable,
 
-	ModelH2S: fullyCapable,
-
-	ModelH2D: fullyCapable,
-
-	ModelH2DPro: fullyCapable,
+	ModelH2S: fullyCapable.withLimits(Limits{
+		BuildVolume:   Volume{340, 320, 340},
+		MaxNozzleTemp: 350,
+		MaxBedTemp:    120,
+	}),
+
+	// Single nozzle volume, the combined dual nozzle area is smaller.
+	ModelH2D: fullyCapable.withLimits(Limits{
+		// 350 mm is the combined width, each nozzle reaches 325 mm and both together 300 mm
+		BuildVolume:           Volume{325, 320, 325},
+		DualNozzleBuildVolume: Volume{300, 320, 325},
+		MaxNozzleTemp:         350,
+		MaxBedTemp:            120,
+	}),
+
+	ModelH2DPro: fullyCapable.withLimits(Limits{
+		// 350 mm is the combined width, each nozzle reaches 325 mm and both together 300 mm
+		BuildVolume:           Volume{325, 320, 325},
+		DualNozzleBuildVolume: Volume{300, 320, 325},
+		MaxNozzleTemp:         350,
+		MaxBedTemp:            120,
+	}),
 
 	ModelH2C: fullyCapable,
 }
@@ -103,3 +168,8 @@ func SupportsFan(m Model, f Fan) bool {
 func SupportsLight(m Model, l Light) bool {
 	return slices.Contains(models[m].CapableLights, l)
 }
+
+// Capabilities returns the capability mask of a given [Model].
+func Capabilities(m Model) Capability {
+	return models[m].Capabilities
+}
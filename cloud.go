@@ -0,0 +1,44 @@
+package bambulabs_api
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// CloudConfig connects to a printer through the Bambu Cloud MQTT broker instead of the local network, for printers not in LAN-only mode.
+// The topics and messages are the same so the whole [Printer] API works remotely except for file access, which needs FTP on the LAN.
+type CloudConfig struct {
+	// Broker is the host:port of the cloud MQTT broker of the accounts region.
+	Broker string
+	// UserID is the numeric id of the Bambu Lab account owning the printer, the broker expects the username "u_{UserID}".
+	UserID string
+	// Token is the access token of the account, used as the MQTT password.
+	Token string
+}
+
+// Username returns the MQTT username of the account.
+func (c CloudConfig) Username() string {
+	return "u_" + c.UserID
+}
+
+// mqttConfig fills the connection settings of m for the cloud broker.
+// The broker presents a regular certificate for its host name which is verified against t.RootCAs, or the system roots if nil, unless t is [TLSInsecure].
+func (c CloudConfig) mqttConfig(m *mqtt.MqttConfig, t TLSConfig) error {
+	if c.Broker == "" || c.UserID == "" || c.Token == "" {
+		return fmt.Errorf("%w: broker, user id and token are required", ErrInvalidCloudConfig)
+	}
+
+	host, _, err := net.SplitHostPort(c.Broker)
+	if err != nil {
+		return fmt.Errorf("%w: broker %q: %v", ErrInvalidCloudConfig, c.Broker, err)
+	}
+
+	m.Broker = c.Broker
+	m.Username = c.Username()
+	m.AccessCode = c.Token
+	m.TLS = &tls.Config{ServerName: host, RootCAs: t.RootCAs, InsecureSkipVerify: t.Mode == TLSInsecure}
+	return nil
+}
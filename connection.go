@@ -0,0 +1,136 @@
+package bambulabs_api
+
+import (
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// ConnectionState is the state of the MQTT connection to a printer.
+type ConnectionState uint8
+
+const (
+	// Connected means the printer is reachable and subscribed to.
+	Connected ConnectionState = iota
+	// Reconnecting means the connection was lost and is being re-established automatically.
+	Reconnecting
+	// Disconnected means the connection was lost, LastError holds the cause.
+	Disconnected
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case Connected:
+		return "Connected"
+	case Reconnecting:
+		return "Reconnecting"
+	case Disconnected:
+		return "Disconnected"
+	default:
+		return "Unknown"
+	}
+}
+
+// ConnectionStatus describes the connection to a printer and the freshness of its state, see [Printer.ConnectionStatus].
+type ConnectionStatus struct {
+	State ConnectionState
+	// LastError is the cause of the last lost connection or failed reconnect attempt, it is kept after reconnecting.
+	LastError error
+	// LastMessage is when the printer last sent anything, zero if nothing was received yet.
+	LastMessage time.Time
+	// Stale is set when no message arrived within the stale window of the model, see [Config.StaleAfter].
+	Stale bool
+}
+
+// staleWindow returns how long a printer of model m may stay silent before its state is considered stale.
+// The X1 and H2 series push a report every second, the P and A series only send changes and a full report every few minutes.
+func staleWindow(m Model) time.Duration {
+	switch m {
+	case ModelX1C, ModelX1E, ModelH2S, ModelH2D, ModelH2DPro, ModelH2, ModelH2C, ModelX2D:
+		return 30 * time.Second
+	default:
+		return 6 * time.Minute
+	}
+}
+
+// ConnectionStatus returns the current connection status, it is safe to call at any time.
+func (p *printer) ConnectionStatus() ConnectionStatus {
+	p.connMu.Lock()
+	defer p.connMu.Unlock()
+
+	return p.conn
+}
+
+// handleConnEvent applies a change of the MQTT connection, only called by the state loop.
+func (p *printer) handleConnEvent(ev mqtt.ConnEvent) {
+	var state ConnectionState
+	switch ev.State {
+	case mqtt.StateConnected:
+		state = Connected
+	case mqtt.StateReconnecting:
+		state = Reconnecting
+	default:
+		state = Disconnected
+	}
+
+	p.connMu.Lock()
+	changed := p.conn.State != state
+	p.conn.State = state
+	if ev.Err != nil {
+		p.conn.LastError = ev.Err
+	}
+	p.connMu.Unlock()
+
+	if state == Connected {
+		// reports sent while disconnected are lost and delta models would never resend unchanged fields
+		go func() {
+			if err := p.RequestUpdate(p.ctx); err != nil && p.ctx.Err() == nil {
+				p.log.Warn("pushall after connecting failed", "error", err)
+			}
+		}()
+	}
+
+	if !changed {
+		return
+	}
+	switch state {
+	case Connected:
+		p.log.Info("mqtt connected")
+	case Reconnecting:
+		p.log.Info("mqtt reconnecting")
+	case Disconnected:
+		p.log.Warn("mqtt connection lost", "error", ev.Err)
+	}
+	p.emit(connectionEvents[state], ev.Err)
+}
+
+var connectionEvents = map[ConnectionState]EventType{
+	Connected:    EventConnected,
+	Reconnecting: EventReconnecting,
+	Disconnected: EventDisconnected,
+}
+
+// messageReceived records that the printer sent something, clearing the stale flag. Only called by the state loop.
+func (p *printer) messageReceived() {
+	p.connMu.Lock()
+	wasStale := p.conn.Stale
+	p.conn.LastMessage = time.Now()
+	p.conn.Stale = false
+	p.connMu.Unlock()
+
+	if wasStale {
+		p.emit(EventStaleCleared, nil)
+	}
+}
+
+// markStale flags the state as stale once, only called by the state loop.
+func (p *printer) markStale() {
+	p.connMu.Lock()
+	wasStale := p.conn.Stale
+	p.conn.Stale = true
+	p.connMu.Unlock()
+
+	if !wasStale {
+		p.emit(EventStale, nil)
+	}
+}
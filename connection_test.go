@@ -0,0 +1,61 @@
+package bambulabs_api
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+func drainEvents(p *printer) []EventType {
+	var types []EventType
+	for {
+		select {
+		case ev := <-p.events:
+			types = append(types, ev.Type)
+		default:
+			return types
+		}
+	}
+}
+
+func TestConnectionEvents(t *testing.T) {
+	p := newTestPrinter(slog.New(slog.DiscardHandler))
+	lost := errors.New("connection reset")
+
+	p.handleConnEvent(mqtt.ConnEvent{State: mqtt.StateDisconnected, Err: lost})
+	p.handleConnEvent(mqtt.ConnEvent{State: mqtt.StateReconnecting})
+	p.handleConnEvent(mqtt.ConnEvent{State: mqtt.StateReconnecting, Err: errors.New("connection refused")})
+
+	got := drainEvents(p)
+	if want := []EventType{EventDisconnected, EventReconnecting}; !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+
+	status := p.ConnectionStatus()
+	if status.State != Reconnecting || status.LastError == nil || status.LastError.Error() != "connection refused" {
+		t.Errorf("status = %+v, want reconnecting with the last attempts error", status)
+	}
+}
+
+func TestStaleEvents(t *testing.T) {
+	p := newTestPrinter(slog.New(slog.DiscardHandler))
+
+	p.messageReceived()
+	p.markStale()
+	p.markStale()
+	if !p.ConnectionStatus().Stale {
+		t.Error("state not marked stale")
+	}
+	p.messageReceived()
+
+	got := drainEvents(p)
+	if want := []EventType{EventStale, EventStaleCleared}; !slices.Equal(got, want) {
+		t.Errorf("events = %v, want %v", got, want)
+	}
+	if status := p.ConnectionStatus(); status.Stale || status.LastMessage.IsZero() {
+		t.Errorf("status = %+v, want fresh with a last message time", status)
+	}
+}
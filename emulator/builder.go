@@ -1,7 +1,6 @@
 package emulator
 
 import (
-	crand "crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math/rand/v2"
@@ -12,17 +11,20 @@ import (
 	"github.com/torbenconto/bambulabs_api/internal/mqtt"
 )
 
-type MessageBuilder struct {
+// messageBuilder fabricates plausible report messages, all randomness is drawn from rng so a seed reproduces the same messages.
+type messageBuilder struct {
 	msg *mqtt.Message
+	rng *rand.Rand
 }
 
-func NewMessageBuilder() *MessageBuilder {
-	return &MessageBuilder{
+func newMessageBuilder(rng *rand.Rand) *messageBuilder {
+	return &messageBuilder{
 		msg: &mqtt.Message{},
+		rng: rng,
 	}
 }
 
-func (m *MessageBuilder) SetCapability(capability bambulabs_api.Capability) *MessageBuilder {
+func (m *messageBuilder) SetCapability(capability bambulabs_api.Capability) *messageBuilder {
 	p := &m.msg.Print
 
 	if capability.Has(bambulabs_api.CapabilityAnyAms) {
@@ -41,73 +43,85 @@ func (m *MessageBuilder) SetCapability(capability bambulabs_api.Capability) *Mes
 		p.Ams.Version = 4
 		p.Ams.Ams = append(p.Ams.Ams, mqtt.AMSUnit{
 			ID:       "0",
-			Humidity: strconv.Itoa((randInt(0, 5))),       // "0" -> "4", humidity value
-			Temp:     fmt.Sprintf("%.1f", randRoomTemp()), // room temp in deg C
+			Humidity: strconv.Itoa((m.randInt(0, 5))),       // "0" -> "4", humidity value
+			Temp:     fmt.Sprintf("%.1f", m.randRoomTemp()), // room temp in deg C
 		})
 
 		for _, id := range []string{"0", "1", "2", "3"} {
-			p.Ams.Ams[0].Tray = append(p.Ams.Ams[0].Tray, randTray(id, .25)) // only support 1 ams right now, 25% chance to be empty
+			p.Ams.Ams[0].Tray = append(p.Ams.Ams[0].Tray, m.randTray(id, .25)) // only support 1 ams right now, 25% chance to be empty
 		}
 
 		// set vt_tray (external spool), larger chance to be empty
-		p.VtTray = randTray("254", .75)
+		p.VtTray = m.randTray("254", .75)
 	} else {
-		p.VtTray = randTray("254", .10) // large chance without ams to have non-empty external tray
+		p.VtTray = m.randTray("254", .10) // large chance without ams to have non-empty external tray
 	}
 
 	return m
 }
 
-func (m *MessageBuilder) SetGcodeState(state bambulabs_api.GcodeState) *MessageBuilder {
+// SetLights reports every light of model as off.
+func (m *messageBuilder) SetLights(model bambulabs_api.Model) *messageBuilder {
+	m.msg.Print.LightsReport = nil
+	for _, light := range []bambulabs_api.Light{bambulabs_api.ChamberLight, bambulabs_api.WorkLight} {
+		if bambulabs_api.SupportsLight(model, light) {
+			m.msg.Print.LightsReport = append(m.msg.Print.LightsReport, mqtt.LightReport{Node: string(light), Mode: string(bambulabs_api.LightOff)})
+		}
+	}
+
+	return m
+}
+
+func (m *messageBuilder) SetGcodeState(state bambulabs_api.GcodeState) *messageBuilder {
 	p := &m.msg.Print
 	switch state {
 	case bambulabs_api.PREPARE:
 		m.resetPrintState()
 
 		p.NozzleTargetTemper = 250
-		p.NozzleTemper = randRoomTemp()
+		p.NozzleTemper = m.randRoomTemp()
 
-		bedTemp := randRoomTemp()
-		p.BedTemper = randFloat(bedTemp, bedTemp+10)
-		p.BedTargetTemper = randFloat(40.0, 60.0)
+		bedTemp := m.randRoomTemp()
+		p.BedTemper = m.randFloat(bedTemp, bedTemp+10)
+		p.BedTargetTemper = m.randFloat(40.0, 60.0)
 
-		p.GcodeFilePreparePercent = strconv.Itoa(randInt(0, 99))
+		p.GcodeFilePreparePercent = strconv.Itoa(m.randInt(0, 99))
 
-		p.GcodeStartTime = strconv.FormatInt(time.Now().Add(-time.Duration(randInt(60, 3600))*time.Second).Unix(), 10)
+		p.GcodeStartTime = strconv.FormatInt(time.Now().Add(-time.Duration(m.randInt(60, 3600))*time.Second).Unix(), 10)
 
 		p.SubtaskName = "test"
 		p.SubtaskID = "test"
-		p.TaskID = strconv.Itoa(randInt(100000, 999999))
-		p.ProjectID = strconv.Itoa(randInt(100000, 999999))
-		p.ProfileID = strconv.Itoa(randInt(100000, 999999))
+		p.TaskID = strconv.Itoa(m.randInt(100000, 999999))
+		p.ProjectID = strconv.Itoa(m.randInt(100000, 999999))
+		p.ProfileID = strconv.Itoa(m.randInt(100000, 999999))
 		p.QueueNumber = 0
 
 		p.GcodeFile = "example.gcode"
 		p.PrintType = "local"
 
-		p.McRemainingTime = randInt(300, 7200)
+		p.McRemainingTime = m.randInt(300, 7200)
 
-		p.TotalLayerNum = randInt(100, 400)
+		p.TotalLayerNum = m.randInt(100, 400)
 
 		p.GcodeState = string(bambulabs_api.PREPARE)
 	case bambulabs_api.RUNNING:
 		m.resetPrintState()
-		totalLayers := randInt(100, 400)
-		currentLayer := randInt(1, totalLayers)
+		totalLayers := m.randInt(100, 400)
+		currentLayer := m.randInt(1, totalLayers)
 
 		p.LayerNum = currentLayer
 		p.TotalLayerNum = totalLayers
 		p.McPercent = int(float64(currentLayer) / float64(totalLayers) * 100)
 
-		p.McPrintStage = strconv.Itoa(randInt(2, 5))
-		p.McPrintSubStage = randInt(0, 3)
-		p.McRemainingTime = randInt(300, 7200)
+		p.McPrintStage = strconv.Itoa(m.randInt(2, 5))
+		p.McPrintSubStage = m.randInt(0, 3)
+		p.McRemainingTime = m.randInt(300, 7200)
 		p.McPrintErrorCode = "0"
 
-		p.NozzleTargetTemper = randFloat(200, 230)
-		p.NozzleTemper = p.NozzleTargetTemper - randFloat(0, 5)
+		p.NozzleTargetTemper = m.randFloat(200, 230)
+		p.NozzleTemper = p.NozzleTargetTemper - m.randFloat(0, 5)
 
-		bed := randFloat(50.0, 60.0)
+		bed := m.randFloat(50.0, 60.0)
 		p.BedTemper = bed
 		p.BedTargetTemper = bed
 
@@ -119,13 +133,13 @@ func (m *MessageBuilder) SetGcodeState(state bambulabs_api.GcodeState) *MessageB
 		p.GcodeState = string(bambulabs_api.RUNNING)
 		p.GcodeFile = "example.gcode"
 		p.GcodeFilePreparePercent = "100"
-		p.GcodeStartTime = strconv.FormatInt(time.Now().Add(-time.Duration(randInt(60, 3600))*time.Second).Unix(), 10)
+		p.GcodeStartTime = strconv.FormatInt(time.Now().Add(-time.Duration(m.randInt(60, 3600))*time.Second).Unix(), 10)
 
 		p.SubtaskName = "test"
 		p.SubtaskID = "test"
-		p.TaskID = strconv.Itoa(randInt(100000, 999999))
-		p.ProjectID = strconv.Itoa(randInt(100000, 999999))
-		p.ProfileID = strconv.Itoa(randInt(100000, 999999))
+		p.TaskID = strconv.Itoa(m.randInt(100000, 999999))
+		p.ProjectID = strconv.Itoa(m.randInt(100000, 999999))
+		p.ProfileID = strconv.Itoa(m.randInt(100000, 999999))
 		p.QueueNumber = 0
 
 	case bambulabs_api.IDLE:
@@ -138,7 +152,7 @@ func (m *MessageBuilder) SetGcodeState(state bambulabs_api.GcodeState) *MessageB
 	return m
 }
 
-func (m *MessageBuilder) resetPrintState() {
+func (m *messageBuilder) resetPrintState() {
 	p := &m.msg.Print
 
 	p.LayerNum = 0
@@ -166,14 +180,14 @@ func (m *MessageBuilder) resetPrintState() {
 	p.GcodeStartTime = "0"
 }
 
-func randTray(id string, emptyChance float32) mqtt.Tray {
-	if rand.Float32() < emptyChance {
+func (m *messageBuilder) randTray(id string, emptyChance float32) mqtt.Tray {
+	if m.rng.Float32() < emptyChance {
 		return mqtt.Tray{
 			ID: id,
 		}
 	}
 
-	trayColor := randColor()
+	trayColor := m.randColor()
 
 	return mqtt.Tray{
 		ID: id,
@@ -205,26 +219,23 @@ func randTray(id string, emptyChance float32) mqtt.Tray {
 }
 
 // random RGBA color
-func randColor() string {
-	bytes := make([]byte, 3)
-	crand.Read(bytes) // "It never returns an error, and always fills b entirely."
-
-	bytes = append(bytes, 0xFF)
-	return hex.EncodeToString(bytes)
+func (m *messageBuilder) randColor() string {
+	rgb := m.rng.Uint32()
+	return hex.EncodeToString([]byte{byte(rgb >> 16), byte(rgb >> 8), byte(rgb), 0xFF})
 }
 
-func randInt(min, max int) int {
-	return rand.IntN(max-min) + min
+func (m *messageBuilder) randInt(min, max int) int {
+	return m.rng.IntN(max-min) + min
 }
 
-func randFloat(min, max float64) float64 {
-	return (rand.Float64() * (max - min)) + min
+func (m *messageBuilder) randFloat(min, max float64) float64 {
+	return (m.rng.Float64() * (max - min)) + min
 }
 
-func randRoomTemp() float64 {
-	return randFloat(20.0, 24.0)
+func (m *messageBuilder) randRoomTemp() float64 {
+	return m.randFloat(20.0, 24.0)
 }
 
-func (m *MessageBuilder) Build() *mqtt.Message {
+func (m *messageBuilder) Build() *mqtt.Message {
 	return m.msg
 }
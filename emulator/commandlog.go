@@ -0,0 +1,124 @@
+package emulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// Command is a command received by the emulator.
+type Command struct {
+	// Type is the message type the command was sent as, e.g. "print" or "system".
+	Type       string
+	Command    string
+	SequenceID string
+	// Payload is the raw JSON object of the command, including fields the emulator does not interpret.
+	Payload  json.RawMessage
+	Received time.Time
+}
+
+// commandLog records received commands and wakes up waiters.
+type commandLog struct {
+	mu       sync.Mutex
+	commands []Command
+	notify   chan struct{}
+}
+
+func (l *commandLog) record(t string, cmd incomingCommand, payload json.RawMessage) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.commands = append(l.commands, Command{
+		Type:       t,
+		Command:    cmd.Command,
+		SequenceID: cmd.SequenceID,
+		Payload:    append(json.RawMessage(nil), payload...),
+		Received:   time.Now(),
+	})
+
+	if l.notify != nil {
+		close(l.notify)
+		l.notify = nil
+	}
+}
+
+// Commands returns the commands received so far in order.
+func (e *Emulator) Commands() []Command {
+	e.commands.mu.Lock()
+	defer e.commands.mu.Unlock()
+
+	return append([]Command(nil), e.commands.commands...)
+}
+
+// ClearCommands forgets all recorded commands.
+func (e *Emulator) ClearCommands() {
+	e.commands.mu.Lock()
+	defer e.commands.mu.Unlock()
+
+	e.commands.commands = nil
+}
+
+// WaitCommand blocks until a command with the given name (e.g. "ledctrl") has been received and returns the first one, or ctx is done.
+func (e *Emulator) WaitCommand(ctx context.Context, name string) (Command, error) {
+	for {
+		e.commands.mu.Lock()
+		for _, c := range e.commands.commands {
+			if c.Command == name {
+				e.commands.mu.Unlock()
+				return c, nil
+			}
+		}
+		if e.commands.notify == nil {
+			e.commands.notify = make(chan struct{})
+		}
+		notify := e.commands.notify
+		e.commands.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return Command{}, fmt.Errorf("waiting for %s: %w", name, ctx.Err())
+		case <-notify:
+		}
+	}
+}
+
+// Publish publishes payload on the report topic as is, without touching the emulated state. Useful to send messages the emulator would never produce.
+func (e *Emulator) Publish(payload []byte) {
+	e.publish(fmt.Sprintf("device/%s/report", e.serial), payload)
+}
+
+// PublishPrint merges fields into the print object of the emulated state (e.g. {"gcode_state": "FAILED"}) and publishes the result,
+// later reports keep the changed fields. Fields the state does not model are dropped, use [Emulator.Publish] to send them.
+func (e *Emulator) PublishPrint(fields map[string]any) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	raw, err := json.Marshal(e.state.Print)
+	if err != nil {
+		return err
+	}
+	var current map[string]any
+	if err := json.Unmarshal(raw, &current); err != nil {
+		return err
+	}
+	maps.Copy(current, fields)
+
+	merged, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	var next mqtt.Print
+	if err := json.Unmarshal(merged, &next); err != nil {
+		return fmt.Errorf("apply fields: %w", err)
+	}
+
+	e.state.Print = next
+	e.publishCurrentState()
+	return nil
+}
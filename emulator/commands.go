@@ -0,0 +1,264 @@
+package emulator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/internal/protocol"
+)
+
+const (
+	defaultJobLayers = 10
+	defaultLayerTime = 200 * time.Millisecond
+)
+
+// Results reported in command replies.
+const (
+	resultSuccess = "success"
+	resultFailed  = "failed"
+)
+
+// canceledError is the device error reported after a job is stopped, "The task was canceled."
+const canceledError = 0x0300400C
+
+// commandReply is published on the report topic in answer to a command, echoing its sequence_id.
+type commandReply struct {
+	Command    string `json:"command"`
+	SequenceID string `json:"sequence_id"`
+	Param      string `json:"param,omitempty"`
+	Result     string `json:"result"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// handlePrintCommand applies a print command to the state and replies, e.mu must be held.
+func (e *Emulator) handlePrintCommand(cmd incomingCommand) {
+	var err error
+
+	switch cmd.Command {
+	case "gcode_line":
+		err = e.gcodeLine(cmd.Param)
+	case "pause":
+		err = e.transition(bambulabs_api.PAUSE, bambulabs_api.RUNNING, bambulabs_api.PREPARE)
+	case "resume":
+		err = e.transition(bambulabs_api.RUNNING, bambulabs_api.PAUSE)
+		if err == nil {
+			// resuming acknowledges the error that paused the job
+			e.clearErrors()
+		}
+	case "stop":
+		err = e.stopJob()
+	case "project_file":
+		err = e.startJob(cmd)
+	case "ams_change_filament":
+		err = e.changeFilament(cmd.Target)
+	default:
+		err = fmt.Errorf("unsupported command %q", cmd.Command)
+	}
+
+	e.reply(protocol.Print, cmd, err)
+	e.publishCurrentState()
+}
+
+// handleSystemCommand applies a system command to the state and replies, e.mu must be held.
+func (e *Emulator) handleSystemCommand(cmd incomingCommand) {
+	var err error
+
+	switch cmd.Command {
+	case "ledctrl":
+		err = e.setLight(cmd.LedNode, cmd.LedMode)
+	default:
+		err = fmt.Errorf("unsupported command %q", cmd.Command)
+	}
+
+	e.reply(protocol.System, cmd, err)
+	e.publishCurrentState()
+}
+
+func (e *Emulator) reply(t protocol.MessageType, cmd incomingCommand, err error) {
+	r := commandReply{Command: cmd.Command, SequenceID: cmd.SequenceID, Param: cmd.Param, Result: resultSuccess}
+	if err != nil {
+		r.Result = resultFailed
+		r.Reason = err.Error()
+	}
+
+	serialized, mErr := json.Marshal(map[string]commandReply{string(t): r})
+	if mErr != nil {
+		e.log.Error("marshal command reply", "command", cmd.Command, "error", mErr)
+		return
+	}
+
+	e.publish(fmt.Sprintf("device/%s/report", e.serial), serialized)
+}
+
+func (e *Emulator) setLight(node, mode string) error {
+	switch bambulabs_api.LightMode(mode) {
+	case bambulabs_api.LightOn, bambulabs_api.LightOff, bambulabs_api.LightFlashing:
+	default:
+		return fmt.Errorf("unknown light mode %q", mode)
+	}
+
+	lights := e.state.Print.LightsReport
+	for i := range lights {
+		if lights[i].Node == node {
+			lights[i].Mode = mode
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown light %q", node)
+}
+
+// gcodeLine interprets the G-code that changes reported state, other lines are accepted and ignored.
+func (e *Emulator) gcodeLine(param string) error {
+	p := &e.state.Print
+
+	for _, line := range strings.Split(param, "\n") {
+		line, _, _ = strings.Cut(line, ";")
+		fields := strings.Fields(strings.ToUpper(line))
+		if len(fields) == 0 {
+			continue
+		}
+
+		args := make(map[byte]float64)
+		for _, f := range fields[1:] {
+			v, err := strconv.ParseFloat(f[1:], 64)
+			if err != nil {
+				return fmt.Errorf("malformed argument %q in %q", f, line)
+			}
+			args[f[0]] = v
+		}
+
+		switch fields[0] {
+		case "M104", "M109":
+			p.NozzleTargetTemper = args['S']
+		case "M140", "M190":
+			p.BedTargetTemper = args['S']
+		case "M106", "M107":
+			speed := args['S']
+			if fields[0] == "M107" {
+				speed = 0
+			}
+			fan, ok := args['P']
+			if !ok {
+				fan = float64(bambulabs_api.PartCoolingFan)
+			}
+			if err := e.setFan(bambulabs_api.Fan(fan), speed); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setFan stores a 0-255 speed the way printers report it, as a string on a 0-15 scale.
+func (e *Emulator) setFan(fan bambulabs_api.Fan, speed float64) error {
+	p := &e.state.Print
+	level := strconv.Itoa(int(math.Round(math.Min(math.Max(speed, 0), 255) / 255 * 15)))
+
+	switch fan {
+	case bambulabs_api.PartCoolingFan:
+		p.CoolingFanSpeed = level
+	case bambulabs_api.AuxiliaryFan:
+		p.BigFan1Speed = level
+	case bambulabs_api.ChamberFan:
+		p.BigFan2Speed = level
+	default:
+		return fmt.Errorf("unknown fan P%d", fan)
+	}
+
+	return nil
+}
+
+// transition moves the job to state if it currently is in one of from.
+func (e *Emulator) transition(state bambulabs_api.GcodeState, from ...bambulabs_api.GcodeState) error {
+	current := bambulabs_api.GcodeState(e.state.Print.GcodeState)
+	for _, f := range from {
+		if current == f {
+			e.setGcodeState(state)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot move from %s to %s", current, state)
+}
+
+// changeFilament loads the target tray at once, real printers report tray_tar while the change is in progress.
+func (e *Emulator) changeFilament(target *int) error {
+	if target == nil {
+		return fmt.Errorf("missing target")
+	}
+	if len(e.state.Print.Ams.Ams) == 0 {
+		return fmt.Errorf("no ams")
+	}
+
+	id := strconv.Itoa(*target)
+	e.state.Print.Ams.TrayTar = id
+	e.state.Print.Ams.TrayNow = id
+	return nil
+}
+
+// clearErrors removes all HMS and device errors, e.mu must be held.
+func (e *Emulator) clearErrors() {
+	p := &e.state.Print
+	p.HmsErrors = nil
+	p.PrintError = 0
+	p.McPrintErrorCode = "0"
+}
+
+func (e *Emulator) stopJob() error {
+	if err := e.transition(bambulabs_api.FAILED, bambulabs_api.PREPARE, bambulabs_api.RUNNING, bambulabs_api.PAUSE); err != nil {
+		return err
+	}
+
+	if e.jobCancel != nil {
+		e.jobCancel()
+		e.jobCancel = nil
+	}
+	e.state.Print.PrintError = canceledError
+	return nil
+}
+
+// startJob simulates a job for a project_file command, preparing and then printing jobLayers layers.
+func (e *Emulator) startJob(cmd incomingCommand) error {
+	switch bambulabs_api.GcodeState(e.state.Print.GcodeState) {
+	case bambulabs_api.PREPARE, bambulabs_api.RUNNING, bambulabs_api.PAUSE:
+		return fmt.Errorf("printer is busy")
+	}
+
+	ctx, cancel := context.WithCancel(e.ctx)
+	e.jobCancel = cancel
+
+	e.clearErrors()
+	e.applyStep(Step{State: bambulabs_api.PREPARE, TotalLayers: e.jobLayers})
+
+	p := &e.state.Print
+	p.GcodeFile = cmd.Param
+	p.SubtaskName = cmd.SubtaskName
+	if p.SubtaskName == "" {
+		p.SubtaskName = strings.TrimSuffix(strings.TrimSuffix(path.Base(cmd.URL), ".3mf"), ".gcode")
+	}
+
+	steps := []Step{
+		{State: bambulabs_api.RUNNING, Layers: e.jobLayers, Duration: Duration(time.Duration(e.jobLayers) * e.layerTime)},
+		{State: bambulabs_api.FINISH},
+	}
+	go func() {
+		defer cancel()
+
+		// let the prepare phase be observed before printing starts
+		if err := sleep(ctx, e.layerTime); err != nil {
+			return
+		}
+		_ = e.play(ctx, steps)
+	}()
+
+	return nil
+}
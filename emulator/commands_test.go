@@ -0,0 +1,112 @@
+package emulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+	"github.com/torbenconto/bambulabs_api/internal/protocol"
+)
+
+func startEmulator(t *testing.T) *Emulator {
+	t.Helper()
+
+	e, err := Start(context.Background(), Config{Model: bambulabs_api.ModelX1C, JobLayers: 3, LayerTime: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	t.Cleanup(e.Stop)
+	return e
+}
+
+func (e *Emulator) snapshot() mqtt.Print {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.state.Print
+}
+
+func (e *Emulator) waitGcodeState(t *testing.T, state bambulabs_api.GcodeState) mqtt.Print {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if p := e.snapshot(); p.GcodeState == string(state) {
+			return p
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %s, state is %s", state, e.snapshot().GcodeState)
+	return mqtt.Print{}
+}
+
+func TestProjectFileJob(t *testing.T) {
+	e := startEmulator(t)
+
+	e.dispatch(protocol.Print, incomingCommand{Command: "project_file", Param: "Metadata/plate_1.gcode", URL: "ftp:///benchy.gcode.3mf"})
+
+	p := e.waitGcodeState(t, bambulabs_api.PREPARE)
+	if p.SubtaskName != "benchy" || p.GcodeFile != "Metadata/plate_1.gcode" {
+		t.Errorf("job = %q %q, want benchy from plate 1", p.SubtaskName, p.GcodeFile)
+	}
+
+	e.waitGcodeState(t, bambulabs_api.RUNNING)
+	e.dispatch(protocol.Print, incomingCommand{Command: "pause"})
+	paused := e.waitGcodeState(t, bambulabs_api.PAUSE)
+
+	time.Sleep(5 * e.layerTime)
+	if got := e.snapshot().LayerNum; got != paused.LayerNum {
+		t.Errorf("paused job progressed from layer %d to %d", paused.LayerNum, got)
+	}
+
+	e.dispatch(protocol.Print, incomingCommand{Command: "resume"})
+	if p := e.waitGcodeState(t, bambulabs_api.FINISH); p.LayerNum != 3 || p.McPercent != 100 {
+		t.Errorf("finished at layer %d (%d%%), want 3 (100%%)", p.LayerNum, p.McPercent)
+	}
+}
+
+func TestStopJob(t *testing.T) {
+	e := startEmulator(t)
+
+	e.dispatch(protocol.Print, incomingCommand{Command: "stop"})
+	if p := e.snapshot(); p.GcodeState != string(bambulabs_api.IDLE) {
+		t.Fatalf("stop while idle moved to %s", p.GcodeState)
+	}
+
+	e.dispatch(protocol.Print, incomingCommand{Command: "project_file", Param: "Metadata/plate_1.gcode", URL: "ftp:///cube.3mf"})
+	e.waitGcodeState(t, bambulabs_api.RUNNING)
+	e.dispatch(protocol.Print, incomingCommand{Command: "stop"})
+
+	p := e.waitGcodeState(t, bambulabs_api.FAILED)
+	if p.PrintError != canceledError {
+		t.Errorf("print_error = %#x, want %#x", p.PrintError, canceledError)
+	}
+
+	time.Sleep(5 * e.layerTime)
+	if got := e.snapshot().GcodeState; got != string(bambulabs_api.FAILED) {
+		t.Errorf("stopped job moved on to %s", got)
+	}
+}
+
+func TestGcodeLine(t *testing.T) {
+	e := &Emulator{capability: bambulabs_api.CapabilityAnyAms}
+	e.Seed(1)
+
+	if err := e.gcodeLine("M104 S220\nM140 S65 ; bed\nM106 P3 S128\nM106 S255\nG28"); err != nil {
+		t.Fatalf("gcodeLine() error = %v", err)
+	}
+
+	p := e.state.Print
+	if p.NozzleTargetTemper != 220 || p.BedTargetTemper != 65 {
+		t.Errorf("targets = %v/%v, want 220/65", p.NozzleTargetTemper, p.BedTargetTemper)
+	}
+	if p.BigFan2Speed != "8" || p.CoolingFanSpeed != "15" {
+		t.Errorf("fans = chamber %q part %q, want 8 and 15", p.BigFan2Speed, p.CoolingFanSpeed)
+	}
+
+	if err := e.gcodeLine("M106 P9 S255"); err == nil {
+		t.Error("gcodeLine() accepted an unknown fan")
+	}
+}
@@ -0,0 +1,70 @@
+package emulator
+
+import (
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+)
+
+const (
+	defaultHost       = "127.0.0.1"
+	defaultSerial     = "EMU000000000001"
+	defaultAccessCode = "12345678"
+)
+
+// Config configures an [Emulator], zero values are replaced by the defaults documented on each field.
+type Config struct {
+	// Model selects the emulated printer, it decides the lights and report interval.
+	Model bambulabs_api.Model
+	// Capabilities overrides the capabilities of Model, zero uses the models own.
+	Capabilities bambulabs_api.Capability
+
+	// SerialNumber defaults to "EMU000000000001" and AccessCode to "12345678".
+	SerialNumber string
+	AccessCode   string
+	// Credentials are additional username and password pairs the broker accepts, such as "u_{user id}" and a token
+	// to stand in for the Bambu Cloud broker in [bambulabs_api.CloudConfig] tests.
+	Credentials map[string]string
+
+	// Host defaults to 127.0.0.1, MQTTPort to a free port the broker binds when the emulator starts.
+	Host     string
+	MQTTPort int
+
+	// Seed makes every fabricated value reproducible, see [Emulator.Seed].
+	Seed uint64
+	// Profile controls how the state is reported, defaults to [ProfileFor] Model. A zero Interval is taken from the models profile.
+	Profile ReportProfile
+
+	// JobLayers and LayerTime shape jobs started with a project_file command, defaulting to 10 layers of 200ms.
+	JobLayers int
+	LayerTime time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.Capabilities == 0 {
+		c.Capabilities = bambulabs_api.Capabilities(c.Model)
+	}
+	if c.SerialNumber == "" {
+		c.SerialNumber = defaultSerial
+	}
+	if c.AccessCode == "" {
+		c.AccessCode = defaultAccessCode
+	}
+	if c.Host == "" {
+		c.Host = defaultHost
+	}
+	if c.Profile == (ReportProfile{}) {
+		c.Profile = ProfileFor(c.Model)
+	}
+	if c.Profile.Interval == 0 {
+		c.Profile.Interval = ProfileFor(c.Model).Interval
+	}
+	if c.JobLayers == 0 {
+		c.JobLayers = defaultJobLayers
+	}
+	if c.LayerTime == 0 {
+		c.LayerTime = defaultLayerTime
+	}
+
+	return c
+}
@@ -0,0 +1,284 @@
+// Package emulator runs a fake Bambu Lab printer on the local machine for hermetic tests.
+// It serves the printers MQTT interface, reports a seeded state, models the effect of common commands,
+// records every command it receives and plays scripted [Scenario]s.
+package emulator
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+	"github.com/torbenconto/bambulabs_api/internal/protocol"
+)
+
+type incomingCommand struct {
+	Command    string `json:"command"`
+	SequenceID string `json:"sequence_id"`
+	Param      string `json:"param,omitempty"`
+
+	// ledctrl
+	LedNode string `json:"led_node,omitempty"`
+	LedMode string `json:"led_mode,omitempty"`
+
+	// project_file
+	URL         string `json:"url,omitempty"`
+	SubtaskName string `json:"subtask_name,omitempty"`
+
+	// ams_change_filament
+	Target *int `json:"target,omitempty"`
+}
+
+// Emulator is a fake printer serving the MQTT interface of a given model on the local machine.
+// Clients connect to it like to a real printer using [Emulator.PrinterConfig].
+type Emulator struct {
+	ctx                     context.Context
+	cancel                  context.CancelFunc
+	log                     *slog.Logger
+	port                    int
+	host                    string
+	broker                  *mochi.Server
+	done                    chan struct{}
+	targetModel             bambulabs_api.Model
+	serial                  string
+	accessCode              string
+	credentials             map[string]string
+	capability              bambulabs_api.Capability
+	unsolicitedUpdateTicker *time.Ticker
+
+	// cert is the printer certificate, signed by ca with the serial number as common name
+	cert *x509.Certificate
+	ca   *x509.Certificate
+
+	// mu guards the state and rng, which are shared by the command handlers and running scenarios.
+	mu    sync.Mutex
+	rng   *rand.Rand
+	state *mqtt.Message
+
+	// job simulated for project_file commands, cancelled by stop
+	jobCancel context.CancelFunc
+	jobLayers int
+	layerTime time.Duration
+
+	commands commandLog
+	reporter reporter
+
+	faultsMu sync.Mutex
+	faults   Faults
+	delayed  chan delayedPublish
+
+	// replaying counts running [Emulator.Replay] calls, periodic reports are suspended while it is non zero.
+	replaying int
+}
+
+// Start starts an emulator for cfg, it runs until [Emulator.Stop] is called or ctx is done.
+func Start(ctx context.Context, cfg Config) (*Emulator, error) {
+	cfg = cfg.withDefaults()
+
+	ctx, cancel := context.WithCancel(ctx)
+	server := mochi.New(&mochi.Options{
+		InlineClient: true,
+	})
+	auth := &authHook{}
+	if err := server.AddHook(auth, nil); err != nil {
+		cancel()
+		return nil, fmt.Errorf("add auth hook %v", err)
+	}
+
+	tlsCfg, cert, ca, err := printerTLS(cfg.SerialNumber, cfg.Host)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("generate tls cert: %v", err)
+	}
+
+	tcp := listeners.NewTCP(listeners.Config{
+		ID:        "torbenconto/bambulabs_api/emulator",
+		Address:   net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.MQTTPort)),
+		TLSConfig: tlsCfg,
+	})
+	// With port 0 the listener binds a free port and keeps it, so emulators started in parallel never race for one.
+	if err := server.AddListener(tcp); err != nil {
+		cancel()
+		return nil, fmt.Errorf("add tcp listener %v", err)
+	}
+	_, port, err := net.SplitHostPort(tcp.Address())
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("tcp listener address: %v", err)
+	}
+	cfg.MQTTPort, _ = strconv.Atoi(port)
+
+	emu := &Emulator{
+		ctx:         ctx,
+		log:         slog.Default().With("emulator", cfg.SerialNumber),
+		host:        cfg.Host,
+		port:        cfg.MQTTPort,
+		broker:      server,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		targetModel: cfg.Model,
+		capability:  cfg.Capabilities,
+		serial:      cfg.SerialNumber,
+		accessCode:  cfg.AccessCode,
+		credentials: maps.Clone(cfg.Credentials),
+		jobLayers:   cfg.JobLayers,
+		layerTime:   cfg.LayerTime,
+		delayed:     make(chan delayedPublish, 256),
+		cert:        cert,
+		ca:          ca,
+	}
+	auth.e = emu
+	emu.Seed(cfg.Seed)
+
+	go func() {
+		if err := server.Serve(); err != nil {
+			return
+		}
+	}()
+
+	emu.reporter.profile = cfg.Profile
+	emu.unsolicitedUpdateTicker = time.NewTicker(cfg.Profile.Interval)
+	go emu.run(ctx)
+	go emu.publishDelayed()
+	return emu, nil
+}
+
+// PrinterConfig returns the configuration a [bambulabs_api.Client] uses to connect to the emulator.
+func (e *Emulator) PrinterConfig() bambulabs_api.Config {
+	return bambulabs_api.Config{
+		Host:         net.ParseIP(e.host),
+		MQTTPort:     e.port,
+		Model:        e.targetModel,
+		AccessCode:   e.accessCode,
+		SerialNumber: e.serial,
+	}
+}
+
+// Certificate returns the certificate the emulator presents, issued to its serial number.
+func (e *Emulator) Certificate() *x509.Certificate {
+	return e.cert
+}
+
+// RootCAs returns a pool holding the certificate authority that signed [Emulator.Certificate], for [bambulabs_api.TLSVerify].
+func (e *Emulator) RootCAs() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(e.ca)
+	return pool
+}
+
+// Port returns the port the MQTT broker listens on.
+func (e *Emulator) Port() int {
+	return e.port
+}
+
+func (e *Emulator) run(ctx context.Context) {
+	defer close(e.done)
+	e.broker.Subscribe(fmt.Sprintf("device/%s/request", e.serial), 1, e.handleCommand)
+	for {
+		select {
+		case <-ctx.Done():
+			_ = e.broker.Close()
+			return
+		case <-e.unsolicitedUpdateTicker.C:
+			e.mu.Lock()
+			if e.replaying == 0 {
+				e.publishPeriodicState()
+			}
+			e.mu.Unlock()
+		}
+	}
+}
+
+func (e *Emulator) handleCommand(_ *mochi.Client, _ packets.Subscription, pk packets.Packet) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(pk.Payload, &envelope); err != nil {
+		return
+	}
+	for t, inner := range envelope {
+		var cmd incomingCommand
+		if err := json.Unmarshal(inner, &cmd); err != nil {
+			continue
+		}
+		e.commands.record(t, cmd, inner)
+		e.dispatch(protocol.MessageType(t), cmd)
+	}
+}
+
+func (e *Emulator) dispatch(t protocol.MessageType, cmd incomingCommand) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch t {
+	case protocol.Print:
+		e.handlePrintCommand(cmd)
+	case protocol.System:
+		e.handleSystemCommand(cmd)
+	case protocol.Pushing:
+		e.publishFullState()
+	}
+}
+
+// Seed resets the emulated printer to a fresh idle state generated from seed, the same seed always produces the same state.
+func (e *Emulator) Seed(seed uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.rng = rand.New(rand.NewPCG(seed, seed))
+	e.state = newMessageBuilder(e.rng).
+		SetCapability(e.capability).
+		SetGcodeState(bambulabs_api.IDLE).
+		SetLights(e.targetModel).
+		Build()
+	e.builder().resetPrintState()
+
+	p := &e.state.Print
+	p.Command = "push_status"
+	p.CoolingFanSpeed, p.BigFan1Speed, p.BigFan2Speed = "0", "0", "0"
+}
+
+// builder returns a [messageBuilder] modifying the current state in place, e.mu must be held.
+func (e *Emulator) builder() *messageBuilder {
+	return &messageBuilder{msg: e.state, rng: e.rng}
+}
+
+// PushUpdate publishes a full report of the current state, as the printer does in answer to pushall.
+func (e *Emulator) PushUpdate() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.publishFullState()
+}
+
+func (e *Emulator) publish(topic string, payload []byte) {
+	f := e.Faults()
+	switch {
+	case f.DropPublishes:
+		return
+	case f.PublishDelay > 0:
+		select {
+		case e.delayed <- delayedPublish{at: time.Now().Add(f.PublishDelay), topic: topic, payload: payload}:
+		case <-e.ctx.Done():
+		}
+	default:
+		_ = e.broker.Publish(topic, payload, false, 0)
+	}
+}
+
+// Stop shuts the emulator down and waits for it to exit.
+func (e *Emulator) Stop() {
+	e.cancel()
+	<-e.done
+	e.unsolicitedUpdateTicker.Stop()
+}
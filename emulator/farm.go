@@ -0,0 +1,92 @@
+package emulator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/torbenconto/bambulabs_api"
+)
+
+// Farm is a group of emulated printers for testing code that manages many printers at once.
+// Like real printers, every emulator runs its own broker on its own port.
+type Farm struct {
+	emulators []*Emulator
+}
+
+// FarmConfigs returns n configs cycling through models, with distinct serial numbers and seeds so every printer reports a different state.
+// The configs can be adjusted before passing them to [StartFarm].
+func FarmConfigs(n int, models ...bambulabs_api.Model) []Config {
+	if len(models) == 0 {
+		models = []bambulabs_api.Model{bambulabs_api.ModelX1C}
+	}
+
+	cfgs := make([]Config, n)
+	for i := range cfgs {
+		cfgs[i] = Config{
+			Model:        models[i%len(models)],
+			SerialNumber: fmt.Sprintf("EMU%012d", i+1),
+			Seed:         uint64(i + 1),
+		}
+	}
+	return cfgs
+}
+
+// StartFarm starts an emulator for every config, stopping those already started if one fails.
+// The farm runs until [Farm.Stop] is called or ctx is done.
+func StartFarm(ctx context.Context, cfgs ...Config) (*Farm, error) {
+	f := &Farm{}
+	seen := make(map[string]bool, len(cfgs))
+
+	for i, cfg := range cfgs {
+		e, err := Start(ctx, cfg)
+		if err == nil && seen[e.serial] {
+			e.Stop()
+			err = fmt.Errorf("duplicate serial number %s", e.serial)
+		}
+		if err != nil {
+			f.Stop()
+			return nil, fmt.Errorf("printer %d: %w", i, err)
+		}
+
+		seen[e.serial] = true
+		f.emulators = append(f.emulators, e)
+	}
+
+	return f, nil
+}
+
+// Emulators returns the printers of the farm in the order of their configs, each can be driven independently.
+func (f *Farm) Emulators() []*Emulator {
+	return append([]*Emulator(nil), f.emulators...)
+}
+
+// Emulator returns the printer with the given serial number, or nil if there is none.
+func (f *Farm) Emulator(serial string) *Emulator {
+	for _, e := range f.emulators {
+		if e.serial == serial {
+			return e
+		}
+	}
+	return nil
+}
+
+// Configs returns the configuration a [bambulabs_api.Client] uses to connect to each printer, in the order of the emulators.
+func (f *Farm) Configs() []bambulabs_api.Config {
+	cfgs := make([]bambulabs_api.Config, len(f.emulators))
+	for i, e := range f.emulators {
+		cfgs[i] = e.PrinterConfig()
+	}
+	return cfgs
+}
+
+// Len returns the number of printers in the farm.
+func (f *Farm) Len() int {
+	return len(f.emulators)
+}
+
+// Stop stops every printer of the farm.
+func (f *Farm) Stop() {
+	for _, e := range f.emulators {
+		e.Stop()
+	}
+}
@@ -0,0 +1,51 @@
+package emulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api"
+)
+
+func TestFarmConfigs(t *testing.T) {
+	cfgs := FarmConfigs(3, bambulabs_api.ModelX1C, bambulabs_api.ModelA1)
+
+	models := []bambulabs_api.Model{bambulabs_api.ModelX1C, bambulabs_api.ModelA1, bambulabs_api.ModelX1C}
+	for i, cfg := range cfgs {
+		if cfg.Model != models[i] {
+			t.Errorf("config %d model = %v, want %v", i, cfg.Model, models[i])
+		}
+	}
+	if cfgs[0].SerialNumber == cfgs[1].SerialNumber || cfgs[0].Seed == cfgs[1].Seed {
+		t.Error("configs share a serial number or seed")
+	}
+}
+
+func TestStartFarm(t *testing.T) {
+	f, err := StartFarm(context.Background(), FarmConfigs(3, bambulabs_api.ModelP1S)...)
+	if err != nil {
+		t.Fatalf("start farm: %v", err)
+	}
+	defer f.Stop()
+
+	ports := map[int]bool{}
+	for _, cfg := range f.Configs() {
+		ports[cfg.MQTTPort] = true
+		if f.Emulator(cfg.SerialNumber) == nil {
+			t.Errorf("no emulator for %s", cfg.SerialNumber)
+		}
+	}
+	if len(ports) != f.Len() {
+		t.Errorf("%d distinct ports for %d printers", len(ports), f.Len())
+	}
+}
+
+func TestStartFarmDuplicateSerial(t *testing.T) {
+	cfgs := FarmConfigs(2)
+	cfgs[1].SerialNumber = cfgs[0].SerialNumber
+
+	if f, err := StartFarm(context.Background(), cfgs...); err == nil {
+		f.Stop()
+		t.Fatal("farm started with a duplicate serial number")
+	}
+}
@@ -0,0 +1,163 @@
+package emulator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+)
+
+// Faults are failures injected into the emulator to cover the error handling of clients, the zero value injects none.
+type Faults struct {
+	// RejectAuth refuses new connections as if the access code was wrong, connected clients are not affected.
+	RejectAuth bool
+	// PublishDelay holds back every message published by the emulator, messages keep their order.
+	PublishDelay time.Duration
+	// DropPublishes silently discards every message published by the emulator, commands are still received and applied.
+	DropPublishes bool
+}
+
+// errDropped is the reason given to clients disconnected by [Emulator.DropClients].
+var errDropped = errors.New("connection dropped by emulator")
+
+// delayedPublish is a message waiting for its [Faults.PublishDelay] to pass.
+type delayedPublish struct {
+	at      time.Time
+	topic   string
+	payload []byte
+}
+
+// SetFaults replaces the injected faults, pass the zero value to restore normal operation.
+func (e *Emulator) SetFaults(f Faults) {
+	e.faultsMu.Lock()
+	defer e.faultsMu.Unlock()
+
+	e.faults = f
+}
+
+// Faults returns the currently injected faults.
+func (e *Emulator) Faults() Faults {
+	e.faultsMu.Lock()
+	defer e.faultsMu.Unlock()
+
+	return e.faults
+}
+
+// DropClients disconnects every connected client without a DISCONNECT, like a printer losing Wi-Fi, and returns how many were dropped.
+// Clients with auto reconnect come back unless [Faults.RejectAuth] is set.
+func (e *Emulator) DropClients() int {
+	n := 0
+	for _, cl := range e.broker.Clients.GetAll() {
+		if cl.Net.Inline || cl.Closed() {
+			continue
+		}
+		cl.Stop(errDropped)
+		n++
+	}
+	return n
+}
+
+// ClientIDs returns the MQTT client IDs of the connected clients.
+func (e *Emulator) ClientIDs() []string {
+	var ids []string
+	for _, cl := range e.broker.Clients.GetAll() {
+		if !cl.Net.Inline && !cl.Closed() {
+			ids = append(ids, cl.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// PublishTruncated publishes the first half of a full report, as sent by a printer whose connection broke mid message.
+func (e *Emulator) PublishTruncated() error {
+	payload, err := e.fullReport()
+	if err != nil {
+		return err
+	}
+
+	e.Publish(payload[:len(payload)/2])
+	return nil
+}
+
+// PublishInvalidJSON publishes a report that is not JSON at all.
+func (e *Emulator) PublishInvalidJSON() {
+	e.Publish([]byte(`{"print": {"command": "push_status", "gcode_state": RUNNING}`))
+}
+
+// PublishOversized publishes a valid full report padded to at least size bytes with a top level "padding" field.
+func (e *Emulator) PublishOversized(size int) error {
+	payload, err := e.fullReport()
+	if err != nil {
+		return err
+	}
+
+	if pad := size - len(payload) - len(`,"padding":""`); pad > 0 {
+		var buf bytes.Buffer
+		buf.Write(payload[:len(payload)-1])
+		fmt.Fprintf(&buf, `,"padding":"%s"}`, strings.Repeat("x", pad))
+		payload = buf.Bytes()
+	}
+
+	e.Publish(payload)
+	return nil
+}
+
+// fullReport marshals the current state as a full report without publishing it.
+func (e *Emulator) fullReport() ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return json.Marshal(e.state)
+}
+
+// publishDelayed publishes the messages held back by [Faults.PublishDelay] once their time comes.
+func (e *Emulator) publishDelayed() {
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case p := <-e.delayed:
+			if err := sleep(e.ctx, time.Until(p.at)); err != nil {
+				return
+			}
+			_ = e.broker.Publish(p.topic, p.payload, false, 0)
+		}
+	}
+}
+
+// authHook accepts the printers credentials and [Config.Credentials] unless [Faults.RejectAuth] is set.
+type authHook struct {
+	mochi.HookBase
+	e *Emulator
+}
+
+func (h *authHook) ID() string {
+	return "bambulabs-auth"
+}
+
+func (h *authHook) Provides(b byte) bool {
+	return b == mochi.OnConnectAuthenticate || b == mochi.OnACLCheck
+}
+
+func (h *authHook) OnConnectAuthenticate(cl *mochi.Client, pk packets.Packet) bool {
+	if h.e.Faults().RejectAuth {
+		return false
+	}
+	user, pass := string(pk.Connect.Username), string(pk.Connect.Password)
+	if user == "bblp" {
+		return pass == h.e.accessCode
+	}
+	want, ok := h.e.credentials[user]
+	return ok && pass == want
+}
+
+func (h *authHook) OnACLCheck(cl *mochi.Client, topic string, write bool) bool {
+	return true
+}
@@ -0,0 +1,57 @@
+package emulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/recording"
+)
+
+// Replay publishes the messages a printer sent in a recording made with [recording.Recorder], in order and with their original spacing divided by speed.
+// A speed of zero or less publishes them without delay. Payloads are published as recorded, including invalid ones, and do not change the emulated state.
+// Periodic reports are suspended while replaying so clients only see the recorded traffic, commands are still answered.
+func (e *Emulator) Replay(ctx context.Context, r io.Reader, speed float64) error {
+	e.mu.Lock()
+	e.replaying++
+	e.mu.Unlock()
+	defer func() {
+		e.mu.Lock()
+		e.replaying--
+		e.mu.Unlock()
+	}()
+
+	topic := fmt.Sprintf("device/%s/report", e.serial)
+	rec := recording.NewReader(r)
+	secrets := strings.NewReplacer(recording.RedactedSerial, e.serial, recording.RedactedAccessCode, e.accessCode)
+
+	var last time.Time
+	for {
+		entry, err := rec.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if entry.Direction != recording.In {
+			continue
+		}
+
+		if speed > 0 && !last.IsZero() {
+			if err := sleep(ctx, time.Duration(float64(entry.Time.Sub(last))/speed)); err != nil {
+				return err
+			}
+		}
+		last = entry.Time
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// The serial number and access code are redacted in recordings, substitute the emulated ones so fields like sn match.
+		e.publish(topic, []byte(secrets.Replace(string(entry.Bytes()))))
+	}
+}
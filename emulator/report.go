@@ -0,0 +1,125 @@
+package emulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+)
+
+// Values of the msg field, full reports carry every field while deltas only carry what changed.
+const (
+	msgFull  = 0
+	msgDelta = 1
+)
+
+// ReportProfile describes how a model reports its state.
+type ReportProfile struct {
+	// Interval is the period of unsolicited reports.
+	Interval time.Duration
+	// Delta makes reports carry only the fields that changed since the previous report, periodic deltas are skipped if nothing changed.
+	// Full reports are still sent in answer to pushall.
+	Delta bool
+	// FullInterval is the period of unsolicited full reports of a delta profile, zero sends them only in answer to pushall.
+	FullInterval time.Duration
+}
+
+// ProfileFor returns the report profile of a model's current firmware.
+// The X1 and H2 series push a full report every second, the P and A series send deltas and an occasional full push.
+func ProfileFor(m bambulabs_api.Model) ReportProfile {
+	switch m {
+	case bambulabs_api.ModelX1C, bambulabs_api.ModelX1E,
+		bambulabs_api.ModelH2S, bambulabs_api.ModelH2D, bambulabs_api.ModelH2DPro, bambulabs_api.ModelH2, bambulabs_api.ModelH2C,
+		bambulabs_api.ModelX2D:
+		return ReportProfile{Interval: time.Second}
+	default:
+		return ReportProfile{Interval: time.Second, Delta: true, FullInterval: 5 * time.Minute}
+	}
+}
+
+// reporter tracks what has been reported, e.mu must be held by all callers.
+type reporter struct {
+	profile  ReportProfile
+	sequence int
+	last     map[string]any // print object of the previous report
+	lastFull time.Time
+}
+
+// publishCurrentState reports a state change, as a delta if the profile uses deltas. e.mu must be held.
+func (e *Emulator) publishCurrentState() {
+	e.report(!e.reporter.profile.Delta, false)
+}
+
+// publishFullState answers a pushall with a full report, e.mu must be held.
+func (e *Emulator) publishFullState() {
+	e.report(true, false)
+}
+
+// publishPeriodicState sends the unsolicited report of the profile, e.mu must be held.
+func (e *Emulator) publishPeriodicState() {
+	r := &e.reporter
+	full := !r.profile.Delta || (r.profile.FullInterval > 0 && time.Since(r.lastFull) >= r.profile.FullInterval)
+	e.report(full, true)
+}
+
+// report publishes the state, skipping empty deltas if skipUnchanged is set.
+func (e *Emulator) report(full, skipUnchanged bool) {
+	r := &e.reporter
+
+	current, err := printObject(e.state)
+	if err != nil {
+		e.log.Error("marshal state", "error", err)
+		return
+	}
+
+	fields := current
+	if !full && r.last != nil {
+		fields = make(map[string]any)
+		for k, v := range current {
+			if !reflect.DeepEqual(r.last[k], v) {
+				fields[k] = v
+			}
+		}
+		delete(fields, "sequence_id")
+		if len(fields) == 0 && skipUnchanged {
+			return
+		}
+	} else {
+		full = true
+	}
+
+	r.sequence++
+	fields["command"] = "push_status"
+	fields["sequence_id"] = strconv.Itoa(r.sequence)
+	fields["msg"] = msgDelta
+	if full {
+		fields["msg"] = msgFull
+		r.lastFull = time.Now()
+	}
+
+	serialized, err := json.Marshal(map[string]any{"print": fields})
+	if err != nil {
+		e.log.Error("marshal report", "error", err)
+		return
+	}
+
+	r.last = current
+	e.publish(fmt.Sprintf("device/%s/report", e.serial), serialized)
+}
+
+// printObject returns the print object of a message as generic JSON values, which makes fields comparable.
+func printObject(m any) (map[string]any, error) {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope struct {
+		Print map[string]any `json:"print"`
+	}
+	err = json.Unmarshal(raw, &envelope)
+	return envelope.Print, err
+}
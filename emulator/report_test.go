@@ -0,0 +1,108 @@
+package emulator
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/packets"
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/internal/protocol"
+)
+
+// reports subscribes to the status reports of e, command replies are skipped.
+func reports(t *testing.T, e *Emulator) func() []map[string]any {
+	t.Helper()
+
+	var mu sync.Mutex
+	var got []map[string]any
+
+	err := e.broker.Subscribe("device/"+e.serial+"/report", 2, func(_ *mochi.Client, _ packets.Subscription, pk packets.Packet) {
+		var msg struct {
+			Print map[string]any `json:"print"`
+		}
+		if json.Unmarshal(pk.Payload, &msg) != nil || msg.Print["command"] != "push_status" {
+			return
+		}
+
+		mu.Lock()
+		got = append(got, msg.Print)
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+
+	return func() []map[string]any {
+		time.Sleep(50 * time.Millisecond) // inline delivery is asynchronous
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]any(nil), got...)
+	}
+}
+
+func TestDeltaReports(t *testing.T) {
+	e, err := Start(context.Background(), Config{Model: bambulabs_api.ModelP1S, Profile: ReportProfile{Interval: time.Hour, Delta: true}})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	t.Cleanup(e.Stop)
+	collected := reports(t, e)
+
+	e.dispatch(protocol.Pushing, incomingCommand{Command: "pushall"})
+	e.dispatch(protocol.Print, incomingCommand{Command: "gcode_line", Param: "M104 S200"})
+
+	got := collected()
+	if len(got) != 2 {
+		t.Fatalf("got %d reports, want a full report and a delta", len(got))
+	}
+
+	full, delta := got[0], got[1]
+	if full["msg"] != float64(msgFull) || full["ams"] == nil {
+		t.Errorf("full report = msg %v, ams %v, want msg 0 with every field", full["msg"], full["ams"])
+	}
+	if delta["msg"] != float64(msgDelta) || delta["nozzle_target_temper"] != float64(200) {
+		t.Errorf("delta = %v, want msg 1 with the new nozzle target", delta)
+	}
+	if len(delta) != 4 { // command, msg, sequence_id and the changed field
+		t.Errorf("delta has %d fields, want 4: %v", len(delta), delta)
+	}
+
+	first, _ := strconv.Atoi(full["sequence_id"].(string))
+	second, _ := strconv.Atoi(delta["sequence_id"].(string))
+	if second != first+1 {
+		t.Errorf("sequence ids %d, %d, want increments of one", first, second)
+	}
+
+	// nothing changed, the periodic delta is skipped
+	e.mu.Lock()
+	e.publishPeriodicState()
+	e.mu.Unlock()
+	if n := len(collected()); n != 2 {
+		t.Errorf("got %d reports after an unchanged period, want 2", n)
+	}
+}
+
+func TestFullReports(t *testing.T) {
+	e, err := Start(context.Background(), Config{Model: bambulabs_api.ModelX1C, Profile: ReportProfile{Interval: time.Hour}})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	t.Cleanup(e.Stop)
+	collected := reports(t, e)
+
+	e.dispatch(protocol.Print, incomingCommand{Command: "gcode_line", Param: "M104 S200"})
+	e.mu.Lock()
+	e.publishPeriodicState()
+	e.mu.Unlock()
+
+	for _, r := range collected() {
+		if r["msg"] != float64(msgFull) || r["ams"] == nil {
+			t.Errorf("report = msg %v with %d fields, want full reports only", r["msg"], len(r))
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/hms"
+	"gopkg.in/yaml.v3"
+)
+
+// Duration is a [time.Duration] written as a string such as "1.5s" in scenario files.
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Scenario is a timed sequence of printer states played by [Emulator.Play].
+type Scenario struct {
+	Name string `json:"name" yaml:"name"`
+	// Seed reseeds the emulator before the first step, making every fabricated value reproducible.
+	Seed  uint64 `json:"seed" yaml:"seed"`
+	Steps []Step `json:"steps" yaml:"steps"`
+}
+
+// Step changes the emulated state and then holds for Duration, a report is published whenever the state changes.
+type Step struct {
+	// State moves the job to a new gcode_state, empty keeps the current one.
+	State bambulabs_api.GcodeState `json:"state,omitempty" yaml:"state,omitempty"`
+	// TotalLayers sets the layer count of the job.
+	TotalLayers int `json:"total_layers,omitempty" yaml:"total_layers,omitempty"`
+	// Layers is the number of layers printed during the step, spread evenly over Duration.
+	Layers int `json:"layers,omitempty" yaml:"layers,omitempty"`
+	// HMS codes (e.g. "HMS_0300_0100_0001_0005") raised at the start of the step.
+	HMS []string `json:"hms,omitempty" yaml:"hms,omitempty"`
+	// PrintError is a device error (e.g. "0500-4003") raised at the start of the step.
+	PrintError string `json:"print_error,omitempty" yaml:"print_error,omitempty"`
+	// ClearErrors removes all HMS and device errors before new ones are raised.
+	ClearErrors bool     `json:"clear_errors,omitempty" yaml:"clear_errors,omitempty"`
+	Duration    Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+// BasicPrint returns a scenario printing layers layers of layerTime each: idle, prepare, running, finish.
+func BasicPrint(seed uint64, layers int, layerTime time.Duration) *Scenario {
+	return &Scenario{
+		Name: "basic print",
+		Seed: seed,
+		Steps: []Step{
+			{State: bambulabs_api.IDLE, Duration: Duration(layerTime)},
+			{State: bambulabs_api.PREPARE, TotalLayers: layers, Duration: Duration(layerTime)},
+			{State: bambulabs_api.RUNNING, Layers: layers, Duration: Duration(time.Duration(layers) * layerTime)},
+			{State: bambulabs_api.FINISH},
+		},
+	}
+}
+
+// LoadScenario reads a scenario from a JSON or YAML file, the format is picked by the .json, .yaml or .yml extension.
+func LoadScenario(path string) (*Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseScenario(f, strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// ParseScenario decodes a scenario in the given format, "json" or "yaml", and validates it.
+func ParseScenario(r io.Reader, format string) (*Scenario, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var s Scenario
+	switch strings.ToLower(format) {
+	case "json":
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&s)
+	case "yaml", "yml":
+		dec := yaml.NewDecoder(bytes.NewReader(raw))
+		dec.KnownFields(true)
+		err = dec.Decode(&s)
+	default:
+		return nil, fmt.Errorf("unknown scenario format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode scenario: %w", err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate checks every step for unknown states and malformed error codes.
+func (s *Scenario) Validate() error {
+	for i, step := range s.Steps {
+		switch step.State {
+		case "", bambulabs_api.IDLE, bambulabs_api.PREPARE, bambulabs_api.RUNNING, bambulabs_api.PAUSE, bambulabs_api.FINISH, bambulabs_api.FAILED:
+		default:
+			return fmt.Errorf("step %d: unknown state %q", i, step.State)
+		}
+
+		if step.Layers < 0 || step.TotalLayers < 0 || step.Duration < 0 {
+			return fmt.Errorf("step %d: negative layers or duration", i)
+		}
+		for _, code := range step.HMS {
+			if hms.NewError(code) == nil {
+				return fmt.Errorf("step %d: malformed hms code %q", i, code)
+			}
+		}
+		if step.PrintError != "" && hms.ParsePrintError(step.PrintError) == nil {
+			return fmt.Errorf("step %d: malformed print error %q", i, step.PrintError)
+		}
+	}
+
+	return nil
+}
+
+// Play runs a scenario, blocking until its last step finished or ctx is done.
+// Commands received while a scenario plays act on the same state.
+func (e *Emulator) Play(ctx context.Context, s *Scenario) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	e.Seed(s.Seed)
+	return e.play(ctx, s.Steps)
+}
+
+// play applies steps in order on the current state.
+func (e *Emulator) play(ctx context.Context, steps []Step) error {
+	for _, step := range steps {
+		e.mu.Lock()
+		if err := ctx.Err(); err != nil {
+			e.mu.Unlock()
+			return err // a stopped job must not overwrite the state
+		}
+		e.applyStep(step)
+		e.publishCurrentState()
+		e.mu.Unlock()
+
+		if err := e.hold(ctx, step); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyStep moves the state to the start of a step, e.mu must be held.
+func (e *Emulator) applyStep(step Step) {
+	p := &e.state.Print
+
+	if step.State != "" {
+		e.setGcodeState(step.State)
+	}
+	if step.TotalLayers > 0 {
+		p.TotalLayerNum = step.TotalLayers
+	}
+
+	if step.ClearErrors {
+		e.clearErrors()
+	}
+	for _, code := range step.HMS {
+		p.HmsErrors = append(p.HmsErrors, *hms.NewError(code))
+	}
+	if step.PrintError != "" {
+		p.PrintError = int(hms.ParsePrintError(step.PrintError).Code)
+	}
+}
+
+// setGcodeState transitions the job, keeping its progress where a real printer would, e.mu must be held.
+func (e *Emulator) setGcodeState(state bambulabs_api.GcodeState) {
+	p := &e.state.Print
+	prev := bambulabs_api.GcodeState(p.GcodeState)
+
+	switch state {
+	case bambulabs_api.IDLE:
+		e.builder().resetPrintState()
+	case bambulabs_api.PREPARE:
+		e.builder().SetGcodeState(bambulabs_api.PREPARE)
+		p.LayerNum = 0
+	case bambulabs_api.RUNNING:
+		if prev == bambulabs_api.PAUSE {
+			break // resume keeps the progress
+		}
+		total := p.TotalLayerNum
+		e.builder().SetGcodeState(bambulabs_api.RUNNING)
+		if total > 0 {
+			p.TotalLayerNum = total
+		}
+		p.LayerNum = 0
+		p.McPercent = 0
+	case bambulabs_api.FINISH:
+		p.LayerNum = p.TotalLayerNum
+		p.McPercent = 100
+		p.McRemainingTime = 0
+		p.NozzleTargetTemper = 0
+		p.BedTargetTemper = 0
+	}
+
+	p.GcodeState = string(state)
+}
+
+// hold waits for the duration of a step, advancing the layer count evenly if the step prints layers.
+func (e *Emulator) hold(ctx context.Context, step Step) error {
+	if step.Layers == 0 || step.Duration == 0 {
+		if step.Layers > 0 {
+			e.mu.Lock()
+			e.advanceLayers(step.Layers, 0)
+			e.publishCurrentState()
+			e.mu.Unlock()
+		}
+		return sleep(ctx, time.Duration(step.Duration))
+	}
+
+	layerTime := time.Duration(step.Duration) / time.Duration(step.Layers)
+	for i := step.Layers; i > 0; {
+		if err := sleep(ctx, layerTime); err != nil {
+			return err
+		}
+
+		e.mu.Lock()
+		// a paused job holds its layer until it is resumed
+		if bambulabs_api.GcodeState(e.state.Print.GcodeState) != bambulabs_api.PAUSE {
+			i--
+			e.advanceLayers(1, time.Duration(i)*layerTime)
+			e.publishCurrentState()
+		}
+		e.mu.Unlock()
+	}
+
+	return nil
+}
+
+// advanceLayers prints n layers with the given time left in the step, e.mu must be held.
+func (e *Emulator) advanceLayers(n int, remaining time.Duration) {
+	p := &e.state.Print
+
+	p.LayerNum += n
+	if p.TotalLayerNum < p.LayerNum {
+		p.TotalLayerNum = p.LayerNum
+	}
+	p.McPercent = p.LayerNum * 100 / p.TotalLayerNum
+	p.McRemainingTime = int(remaining.Round(time.Minute) / time.Minute)
+
+	b := e.builder()
+	p.NozzleTemper = p.NozzleTargetTemper - b.randFloat(0, 2)
+	p.BedTemper = p.BedTargetTemper - b.randFloat(0, 1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,92 @@
+package emulator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+)
+
+func TestLoadScenario(t *testing.T) {
+	s, err := LoadScenario("testdata/runout.yaml")
+	if err != nil {
+		t.Fatalf("LoadScenario() error = %v", err)
+	}
+
+	if s.Seed != 42 || len(s.Steps) != 6 {
+		t.Fatalf("scenario = %+v, want seed 42 and 6 steps", s)
+	}
+	pause := s.Steps[3]
+	if pause.State != bambulabs_api.PAUSE || pause.PrintError != "0300-8004" || len(pause.HMS) != 1 {
+		t.Errorf("pause step = %+v", pause)
+	}
+	if time.Duration(s.Steps[2].Duration) != 250*time.Millisecond {
+		t.Errorf("running duration = %v, want 250ms", time.Duration(s.Steps[2].Duration))
+	}
+}
+
+func TestParseScenarioJSON(t *testing.T) {
+	const raw = `{"seed": 7, "steps": [{"state": "RUNNING", "layers": 3, "duration": "1s"}]}`
+
+	s, err := ParseScenario(strings.NewReader(raw), "json")
+	if err != nil {
+		t.Fatalf("ParseScenario() error = %v", err)
+	}
+	if s.Steps[0].Layers != 3 || time.Duration(s.Steps[0].Duration) != time.Second {
+		t.Errorf("step = %+v", s.Steps[0])
+	}
+}
+
+func TestParseScenarioInvalid(t *testing.T) {
+	tests := map[string]string{
+		"state":       `{"steps": [{"state": "FLYING"}]}`,
+		"hms":         `{"steps": [{"hms": ["nope"]}]}`,
+		"print error": `{"steps": [{"print_error": "x-y"}]}`,
+		"duration":    `{"steps": [{"duration": "soon"}]}`,
+		"field":       `{"steps": [{"layer": 3}]}`,
+	}
+	for name, raw := range tests {
+		if _, err := ParseScenario(strings.NewReader(raw), "json"); err == nil {
+			t.Errorf("%s: ParseScenario() error = nil", name)
+		}
+	}
+}
+
+func TestSeedIsDeterministic(t *testing.T) {
+	a := &Emulator{capability: bambulabs_api.CapabilityAnyAms}
+	b := &Emulator{capability: bambulabs_api.CapabilityAnyAms}
+	a.Seed(3)
+	b.Seed(3)
+
+	a.setGcodeState(bambulabs_api.RUNNING)
+	b.setGcodeState(bambulabs_api.RUNNING)
+	a.state.Print.GcodeStartTime, b.state.Print.GcodeStartTime = "", ""
+
+	if !reflect.DeepEqual(a.state, b.state) {
+		t.Error("same seed produced different states")
+	}
+}
+
+func TestApplyStepProgress(t *testing.T) {
+	e := &Emulator{capability: bambulabs_api.CapabilityAnyAms}
+	e.Seed(1)
+
+	e.applyStep(Step{State: bambulabs_api.PREPARE, TotalLayers: 4})
+	e.applyStep(Step{State: bambulabs_api.RUNNING})
+	e.advanceLayers(2, time.Minute)
+
+	p := e.state.Print
+	if p.LayerNum != 2 || p.TotalLayerNum != 4 || p.McPercent != 50 || p.McRemainingTime != 1 {
+		t.Errorf("progress = layer %d/%d %d%% %dmin, want 2/4 50%% 1min", p.LayerNum, p.TotalLayerNum, p.McPercent, p.McRemainingTime)
+	}
+
+	e.applyStep(Step{State: bambulabs_api.PAUSE, HMS: []string{"HMS_0700_2000_0002_0001"}, PrintError: "0300-8004"})
+	e.applyStep(Step{State: bambulabs_api.RUNNING, ClearErrors: true})
+
+	p = e.state.Print
+	if p.LayerNum != 2 || len(p.HmsErrors) != 0 || p.PrintError != 0 {
+		t.Errorf("after resume = layer %d, hms %v, print_error %d, want progress kept and errors cleared", p.LayerNum, p.HmsErrors, p.PrintError)
+	}
+}
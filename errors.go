@@ -1,13 +1,40 @@
 package bambulabs_api
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
 
 var (
 	ErrPrinterExists   = errors.New("printer already present in client")
 	ErrPrinterNotFound = errors.New("printer not found")
+	ErrPrinterClosed   = errors.New("printer closed")
+
+	ErrLightNotSupported  = errors.New("light not supported by this printer model")
+	ErrFanNotSupported    = errors.New("fan not supported by this printer model")
+	ErrAmsNotSupported    = errors.New("ams not supported by this printer model")
+	ErrDryingNotSupported = errors.New("drying not supported by this ams unit")
+
+	ErrInvalidTray     = errors.New("invalid ams tray")
+	ErrInvalidFilament = errors.New("invalid filament setting")
+
+	ErrInvalidDryingSettings = errors.New("invalid drying settings")
+
+	ErrFTPUnavailable   = errors.New("ftp connection unavailable")
+	ErrStateUnavailable = errors.New("no state received from printer yet")
+
+	ErrThumbnailNotFound = errors.New("thumbnail not found")
+
+	ErrUnknownPrinterError = errors.New("not an hms or print error")
+
+	// ErrSessionTakenOver is the cause of a lost connection when another client keeps connecting with the same MQTT client ID.
+	ErrSessionTakenOver = mqtt.ErrSessionTakenOver
 
-	ErrLightNotSupported = errors.New("light not supported by this printer model")
-	ErrFanNotSupported   = errors.New("fan not supported by this printer model")
+	ErrInvalidTLSConfig    = errors.New("invalid tls config")
+	ErrInvalidCloudConfig  = errors.New("invalid cloud config")
+	ErrCertificateRejected = errors.New("printer certificate rejected")
 
-	ErrFTPUnavailable = errors.New("ftp connection unavailable")
+	ErrExceedsBuildVolume = errors.New("job exceeds the build volume of this printer model")
+	ErrExceedsTemperature = errors.New("job exceeds the temperature limits of this printer model")
 )
@@ -0,0 +1,122 @@
+package bambulabs_api
+
+import (
+	"slices"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/hms"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// eventBufferSize bounds the number of undelivered events per printer, events are dropped when a consumer falls behind.
+const eventBufferSize = 64
+
+// EventType identifies the kind of an [Event].
+type EventType uint8
+
+const (
+	// EventError is emitted when the printer reports a new HMS or print error, Err holds an [hms.Error] or [*hms.PrintError].
+	EventError EventType = iota
+	// EventErrorCleared is emitted when a previously reported error disappears from the state, Err holds the cleared error.
+	EventErrorCleared
+	// EventConnected is emitted when the connection to the printer is re-established.
+	EventConnected
+	// EventReconnecting is emitted when the client starts reconnecting after a lost connection.
+	EventReconnecting
+	// EventDisconnected is emitted when the connection is lost, Err holds the cause.
+	EventDisconnected
+	// EventStale is emitted when no message arrived within the stale window, the state may be outdated.
+	EventStale
+	// EventStaleCleared is emitted when a message arrives after the state was stale.
+	EventStaleCleared
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventError:
+		return "Error"
+	case EventErrorCleared:
+		return "Error Cleared"
+	case EventConnected:
+		return "Connected"
+	case EventReconnecting:
+		return "Reconnecting"
+	case EventDisconnected:
+		return "Disconnected"
+	case EventStale:
+		return "Stale"
+	case EventStaleCleared:
+		return "Stale Cleared"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event is a notable change in a printers state or connection, delivered through [Printer.Events].
+type Event struct {
+	Type   EventType
+	Serial string
+	Time   time.Time
+	Err    error
+}
+
+// Events returns a channel of events for this printer. The channel is closed when the printer is closed.
+// Events are dropped if the channel is not drained, all consumers share the same channel.
+func (p *printer) Events() <-chan Event {
+	return p.events
+}
+
+func (p *printer) emit(t EventType, err error) {
+	select {
+	case p.events <- Event{Type: t, Serial: p.cfg.SerialNumber, Time: time.Now(), Err: err}:
+	default:
+		// drop event
+	}
+}
+
+// emitErrorEvents diffs the errors of two consecutive states, prev may be nil for the first state.
+func (p *printer) emitErrorEvents(prev, next *mqtt.Message) {
+	var prevHMS []hms.Error
+	var prevPrintErr *hms.PrintError
+	if prev != nil {
+		prevHMS = prev.Print.HmsErrors
+		prevPrintErr = printError(prev)
+	}
+
+	for _, e := range next.Print.HmsErrors {
+		if !slices.Contains(prevHMS, e) {
+			p.emit(EventError, e)
+		}
+	}
+	for _, e := range prevHMS {
+		if !slices.Contains(next.Print.HmsErrors, e) {
+			p.emit(EventErrorCleared, e)
+		}
+	}
+
+	nextPrintErr := printError(next)
+	if samePrintError(prevPrintErr, nextPrintErr) {
+		return
+	}
+	if prevPrintErr != nil {
+		p.emit(EventErrorCleared, prevPrintErr)
+	}
+	if nextPrintErr != nil {
+		p.emit(EventError, nextPrintErr)
+	}
+}
+
+// printError decodes the device error of a state, print_error takes precedence over the motion controllers mc_print_error_code.
+func printError(m *mqtt.Message) *hms.PrintError {
+	if e := hms.NewPrintError(uint32(m.Print.PrintError)); e != nil {
+		return e
+	}
+	return hms.ParsePrintError(m.Print.McPrintErrorCode)
+}
+
+func samePrintError(a, b *hms.PrintError) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
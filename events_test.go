@@ -0,0 +1,70 @@
+package bambulabs_api
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api/hms"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+func TestEmitErrorEvents(t *testing.T) {
+	p := newTestPrinter(slog.New(slog.DiscardHandler))
+
+	nozzle := *hms.NewError("HMS_0300_0200_0001_0001")
+	fan := *hms.NewError("HMS_0300_0300_0001_0001")
+
+	var first, second mqtt.Message
+	first.Print.HmsErrors = []hms.Error{nozzle}
+	second.Print.HmsErrors = []hms.Error{fan}
+	second.Print.PrintError = 0x05004003
+
+	p.emitErrorEvents(nil, &first)
+	p.emitErrorEvents(&first, &second)
+	p.emitErrorEvents(&second, &second) // unchanged state emits nothing
+
+	want := []struct {
+		typ EventType
+		err string
+	}{
+		{EventError, nozzle.Error()},
+		{EventError, fan.Error()},
+		{EventErrorCleared, nozzle.Error()},
+		{EventError, hms.CurrentDatabase().Languages[hms.DefaultLanguage].Device["0500-4003"]},
+	}
+
+	for _, w := range want {
+		select {
+		case ev := <-p.events:
+			if ev.Type != w.typ || ev.Err.Error() != w.err || ev.Serial != "SERIAL" {
+				t.Errorf("event = %v %q, want %v %q", ev.Type, ev.Err, w.typ, w.err)
+			}
+		default:
+			t.Fatalf("missing event %v %q", w.typ, w.err)
+		}
+	}
+
+	select {
+	case ev := <-p.events:
+		t.Errorf("unexpected event %v %q", ev.Type, ev.Err)
+	default:
+	}
+}
+
+func TestErrorActionCodes(t *testing.T) {
+	e := *hms.NewError("HMS_0700_2000_0002_0001")
+	if got, want := hmsActionCode(e), "0700200000020001"; got != want {
+		t.Errorf("hmsActionCode() = %q, want %q", got, want)
+	}
+
+	pe := hms.ParsePrintError("0500-4003")
+	if got := printErrorCode(pe); got != 0x05004003 {
+		t.Errorf("printErrorCode(*PrintError) = %#x, want 0x05004003", got)
+	}
+	if got := printErrorCode(*pe); got != 0x05004003 {
+		t.Errorf("printErrorCode(PrintError) = %#x, want 0x05004003", got)
+	}
+	if got := printErrorCode((*hms.PrintError)(nil)); got != 0 {
+		t.Errorf("printErrorCode(nil) = %#x, want 0", got)
+	}
+}
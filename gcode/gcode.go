@@ -0,0 +1,480 @@
+// Package gcode provides a streaming analyzer for plain G-code files and the plate G-code embedded in
+// Bambu Studio .3mf projects.
+//
+// The analyzer is intended for pre-flight checks (does this job fit the printer, does it exceed temperature
+// limits) rather than exact simulation, arcs are measured by their endpoints and travel moves are ignored.
+package gcode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/internal/threemf"
+)
+
+// ErrPlateNotFound is returned by [Analyze3MF] when the archive contains no G-code for the requested plate.
+var ErrPlateNotFound = threemf.ErrPlateNotFound
+
+// maxLineLength bounds a single G-code line, slicer config blocks can contain very long lines.
+const maxLineLength = 1 << 20
+
+// Point is a position in printer coordinates (mm).
+type Point struct {
+	X, Y, Z float64
+}
+
+// Bounds is the axis-aligned bounding box of all extruding moves.
+type Bounds struct {
+	Min Point
+	Max Point
+}
+
+// Size returns the extent of the bounding box along each axis.
+func (b Bounds) Size() Point {
+	return Point{
+		X: b.Max.X - b.Min.X,
+		Y: b.Max.Y - b.Min.Y,
+		Z: b.Max.Z - b.Min.Z,
+	}
+}
+
+func (b *Bounds) add(p Point) {
+	b.Min = Point{math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z)}
+	b.Max = Point{math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z)}
+}
+
+func emptyBounds() Bounds {
+	inf := math.Inf(1)
+	return Bounds{
+		Min: Point{inf, inf, inf},
+		Max: Point{-inf, -inf, -inf},
+	}
+}
+
+// Layer describes a single printed layer by its Z height and its thickness above the previous layer.
+type Layer struct {
+	Z      float64
+	Height float64
+}
+
+// Analysis is the result of analyzing a G-code stream.
+//
+// Layers and Bounds only cover the print body when the slicer marks the end of its machine start G-code
+// (Bambu Studio, Orca Slicer and PrusaSlicer all do), so purge lines outside the build plate are not counted.
+// Filament and temperatures cover the entire file.
+type Analysis struct {
+	Layers []Layer
+	Bounds Bounds
+
+	// Filament is the net length of filament (mm) extruded by each tool, keyed by tool index (T0, T1, ...).
+	Filament    map[int]float64
+	ToolChanges int
+
+	MaxNozzleTemp  float64
+	MaxBedTemp     float64
+	MaxChamberTemp float64
+
+	// Values below are read from slicer header comments and are zero when absent.
+	EstimatedTime    time.Duration
+	FilamentDiameter []float64 // mm, per filament
+	FilamentDensity  []float64 // g/cm^3, per filament
+}
+
+// LayerCount returns the number of layers in the print body.
+func (a *Analysis) LayerCount() int {
+	return len(a.Layers)
+}
+
+// FilamentWeight converts the filament length used by tool into grams using the diameter and density from the
+// slicer header, it returns 0 if either is unknown.
+func (a *Analysis) FilamentWeight(tool int) float64 {
+	diameter := valueAt(a.FilamentDiameter, tool)
+	density := a.FilamentDensityOf(tool)
+	if diameter <= 0 || density <= 0 {
+		return 0
+	}
+
+	radius := diameter / 2
+	volume := a.Filament[tool] * math.Pi * radius * radius // mm^3
+	return volume / 1000 * density
+}
+
+// FilamentDensityOf returns the density (g/cm^3) from the slicer header of the filament used by tool, 0 if unknown.
+func (a *Analysis) FilamentDensityOf(tool int) float64 {
+	return valueAt(a.FilamentDensity, tool)
+}
+
+func valueAt(values []float64, i int) float64 {
+	if i < 0 || len(values) == 0 {
+		return 0
+	}
+	// Single values apply to every filament.
+	if i >= len(values) {
+		if len(values) == 1 {
+			return values[0]
+		}
+		return 0
+	}
+	return values[i]
+}
+
+// Analyze reads G-code from r until EOF and returns its [Analysis].
+func Analyze(r io.Reader) (*Analysis, error) {
+	a := newAnalyzer()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	for scanner.Scan() {
+		a.line(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read gcode: %w", err)
+	}
+
+	return a.result(), nil
+}
+
+// Analyze3MF analyzes the sliced G-code for the given 1-based plate of a Bambu Studio .3mf project.
+func Analyze3MF(r io.ReaderAt, size int64, plate int) (*Analysis, error) {
+	archive, err := threemf.Open(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := archive.PlateGcode(plate)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return Analyze(rc)
+}
+
+type analyzer struct {
+	out Analysis
+
+	pos          Point
+	e            float64
+	relativePos  bool
+	relativeE    bool
+	tool         int
+	toolSelected bool
+
+	inBody      bool
+	sawBody     bool
+	bodyBounds  Bounds
+	bodyLayers  []Layer
+	allBounds   Bounds
+	allLayers   []Layer
+	hasExtruded bool
+
+	printTime time.Duration
+	modelTime time.Duration
+	normTime  time.Duration
+}
+
+func newAnalyzer() *analyzer {
+	return &analyzer{
+		out: Analysis{
+			Filament: make(map[int]float64),
+		},
+		bodyBounds: emptyBounds(),
+		allBounds:  emptyBounds(),
+	}
+}
+
+func (a *analyzer) line(line string) {
+	code, comment, hasComment := strings.Cut(line, ";")
+	if hasComment {
+		a.comment(comment)
+	}
+
+	fields := strings.Fields(code)
+	if len(fields) == 0 {
+		return
+	}
+
+	cmd := strings.ToUpper(fields[0])
+	params := parseParams(fields[1:])
+
+	switch cmd {
+	case "G0", "G1", "G2", "G3":
+		a.move(params)
+	case "G90":
+		a.relativePos = false
+	case "G91":
+		a.relativePos = true
+	case "M82":
+		a.relativeE = false
+	case "M83":
+		a.relativeE = true
+	case "G92":
+		a.setPosition(params)
+	case "M104", "M109":
+		a.out.MaxNozzleTemp = max(a.out.MaxNozzleTemp, temperature(params))
+	case "M140", "M190":
+		a.out.MaxBedTemp = max(a.out.MaxBedTemp, temperature(params))
+	case "M141", "M191":
+		a.out.MaxChamberTemp = max(a.out.MaxChamberTemp, temperature(params))
+	default:
+		if tool, ok := parseTool(cmd); ok {
+			a.selectTool(tool)
+		}
+	}
+}
+
+func (a *analyzer) comment(comment string) {
+	comment = strings.TrimSpace(comment)
+
+	switch strings.ToUpper(comment) {
+	case "MACHINE_START_GCODE_END", "LAYER_CHANGE", "CHANGE_LAYER":
+		if !a.sawBody {
+			a.inBody = true
+			a.sawBody = true
+		}
+		return
+	case "MACHINE_END_GCODE_START":
+		a.inBody = false
+		return
+	}
+
+	// Bambu Studio packs several header values into one comment, separated by further semicolons.
+	for part := range strings.SplitSeq(comment, ";") {
+		a.header(part)
+	}
+}
+
+func (a *analyzer) header(part string) {
+	key, value, ok := strings.Cut(part, ":")
+	if !ok {
+		key, value, ok = strings.Cut(part, "=")
+	}
+	if !ok {
+		return
+	}
+	key = strings.ToLower(strings.TrimSpace(key))
+	value = strings.TrimSpace(value)
+
+	switch key {
+	case "total estimated time":
+		a.printTime = parseDuration(value)
+	case "model printing time":
+		a.modelTime = parseDuration(value)
+	case "estimated printing time (normal mode)":
+		a.normTime = parseDuration(value)
+	case "filament_diameter":
+		if a.out.FilamentDiameter == nil {
+			a.out.FilamentDiameter = parseList(value)
+		}
+	case "filament_density":
+		if a.out.FilamentDensity == nil {
+			a.out.FilamentDensity = parseList(value)
+		}
+	}
+}
+
+func (a *analyzer) move(params map[byte]float64) {
+	next := a.pos
+	if a.relativePos {
+		next.X += params['X']
+		next.Y += params['Y']
+		next.Z += params['Z']
+	} else {
+		if v, ok := params['X']; ok {
+			next.X = v
+		}
+		if v, ok := params['Y']; ok {
+			next.Y = v
+		}
+		if v, ok := params['Z']; ok {
+			next.Z = v
+		}
+	}
+
+	var de float64
+	if v, ok := params['E']; ok {
+		// Extrusion stays relative after G90 if M83 was issued, Bambu start G-code relies on this.
+		if a.relativeE || a.relativePos {
+			de = v
+		} else {
+			de = v - a.e
+			a.e = v
+		}
+	}
+	a.out.Filament[a.tool] += de
+
+	// Only moves that deposit material while travelling in XY count towards the printed part.
+	if de > 0 && (next.X != a.pos.X || next.Y != a.pos.Y) {
+		a.extrude(a.pos, next)
+	}
+
+	a.pos = next
+}
+
+func (a *analyzer) extrude(from, to Point) {
+	a.allBounds.add(from)
+	a.allBounds.add(to)
+	a.allLayers = addLayer(a.allLayers, to.Z)
+
+	if a.inBody {
+		a.bodyBounds.add(from)
+		a.bodyBounds.add(to)
+		a.bodyLayers = addLayer(a.bodyLayers, to.Z)
+	}
+
+	a.hasExtruded = true
+}
+
+// addLayer starts a new layer whenever material is deposited above the current top layer.
+func addLayer(layers []Layer, z float64) []Layer {
+	const epsilon = 1e-4
+
+	top := 0.0
+	if len(layers) > 0 {
+		top = layers[len(layers)-1].Z
+	}
+	if z <= top+epsilon {
+		return layers
+	}
+
+	return append(layers, Layer{Z: z, Height: z - top})
+}
+
+func (a *analyzer) setPosition(params map[byte]float64) {
+	// A bare G92 resets every axis.
+	if len(params) == 0 {
+		a.pos = Point{}
+		a.e = 0
+		return
+	}
+	if v, ok := params['X']; ok {
+		a.pos.X = v
+	}
+	if v, ok := params['Y']; ok {
+		a.pos.Y = v
+	}
+	if v, ok := params['Z']; ok {
+		a.pos.Z = v
+	}
+	if v, ok := params['E']; ok {
+		a.e = v
+	}
+}
+
+func (a *analyzer) selectTool(tool int) {
+	if a.toolSelected && tool != a.tool {
+		a.out.ToolChanges++
+	}
+	a.tool = tool
+	a.toolSelected = true
+}
+
+func (a *analyzer) result() *Analysis {
+	out := a.out
+
+	if a.sawBody {
+		out.Bounds = a.bodyBounds
+		out.Layers = a.bodyLayers
+	} else {
+		out.Bounds = a.allBounds
+		out.Layers = a.allLayers
+	}
+	if !a.hasExtruded || len(out.Layers) == 0 {
+		out.Bounds = Bounds{}
+	}
+
+	switch {
+	case a.printTime > 0:
+		out.EstimatedTime = a.printTime
+	case a.modelTime > 0:
+		out.EstimatedTime = a.modelTime
+	default:
+		out.EstimatedTime = a.normTime
+	}
+
+	for tool, length := range out.Filament {
+		if length == 0 {
+			delete(out.Filament, tool)
+		}
+	}
+
+	return &out
+}
+
+func parseParams(fields []string) map[byte]float64 {
+	params := make(map[byte]float64, len(fields))
+	for _, f := range fields {
+		if len(f) < 2 {
+			continue
+		}
+		v, err := strconv.ParseFloat(f[1:], 64)
+		if err != nil {
+			continue
+		}
+		params[f[0]&^0x20] = v // upper-case the parameter letter
+	}
+	return params
+}
+
+func temperature(params map[byte]float64) float64 {
+	if v, ok := params['S']; ok {
+		return v
+	}
+	return params['R']
+}
+
+// parseTool parses tool select commands (T0, T1, ...). Bambu firmware uses T255 and above for special
+// operations such as unloading, so those are not treated as tools.
+func parseTool(cmd string) (int, bool) {
+	n, ok := strings.CutPrefix(cmd, "T")
+	if !ok {
+		return 0, false
+	}
+	tool, err := strconv.Atoi(n)
+	if err != nil || tool < 0 || tool >= 255 {
+		return 0, false
+	}
+	return tool, true
+}
+
+// parseDuration parses slicer durations such as "1d 2h 3m 4s".
+func parseDuration(s string) time.Duration {
+	var total time.Duration
+	for field := range strings.FieldsSeq(s) {
+		if len(field) < 2 {
+			continue
+		}
+		n, err := strconv.Atoi(field[:len(field)-1])
+		if err != nil {
+			continue
+		}
+		switch field[len(field)-1] {
+		case 'd':
+			total += time.Duration(n) * 24 * time.Hour
+		case 'h':
+			total += time.Duration(n) * time.Hour
+		case 'm':
+			total += time.Duration(n) * time.Minute
+		case 's':
+			total += time.Duration(n) * time.Second
+		}
+	}
+	return total
+}
+
+func parseList(s string) []float64 {
+	var values []float64
+	for field := range strings.FieldsFuncSeq(s, func(r rune) bool { return r == ',' || r == ';' }) {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return nil
+		}
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,126 @@
+package gcode
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sample = `; HEADER_BLOCK_START
+; BambuStudio 02.00.00.00
+; model printing time: 1h 10m 26s; total estimated time: 1h 16m 33s
+; total layer number: 2
+; filament_density: 1.24,1.27
+; filament_diameter: 1.75
+; HEADER_BLOCK_END
+M140 S55
+M104 S220
+M83
+G28
+; purge line outside of the plate
+G1 X-10 Y-5 Z0.8 F3000
+G1 X-10 Y50 E10
+; MACHINE_START_GCODE_END
+G92 E0
+M109 S230
+; CHANGE_LAYER
+G1 X10 Y10 Z0.2
+G1 X100 Y10 E5
+G1 X100 Y100 E5
+G1 E-0.8
+T1
+G1 E0.8
+; CHANGE_LAYER
+G1 X10 Y10 Z0.4
+G1 X10 Y100 E5
+; MACHINE_END_GCODE_START
+G1 Z10
+M104 S0
+`
+
+func TestAnalyze(t *testing.T) {
+	a, err := Analyze(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+
+	if got := a.LayerCount(); got != 2 {
+		t.Errorf("LayerCount() = %d, want 2", got)
+	}
+	if got := a.Layers[1].Height; math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("Layers[1].Height = %v, want 0.2", got)
+	}
+
+	wantBounds := Bounds{Min: Point{10, 10, 0.2}, Max: Point{100, 100, 0.4}}
+	if a.Bounds != wantBounds {
+		t.Errorf("Bounds = %+v, want %+v", a.Bounds, wantBounds)
+	}
+
+	if got := a.Filament[0]; math.Abs(got-19.2) > 1e-9 {
+		t.Errorf("Filament[0] = %v, want 19.2", got)
+	}
+	if got := a.Filament[1]; math.Abs(got-5.8) > 1e-9 {
+		t.Errorf("Filament[1] = %v, want 5.8", got)
+	}
+	if a.ToolChanges != 0 {
+		t.Errorf("ToolChanges = %d, want 0 for the initial tool selection", a.ToolChanges)
+	}
+
+	if a.MaxNozzleTemp != 230 || a.MaxBedTemp != 55 {
+		t.Errorf("max temps = %v/%v, want 230/55", a.MaxNozzleTemp, a.MaxBedTemp)
+	}
+
+	if want := time.Hour + 16*time.Minute + 33*time.Second; a.EstimatedTime != want {
+		t.Errorf("EstimatedTime = %v, want %v", a.EstimatedTime, want)
+	}
+
+	if got := a.FilamentWeight(1); got <= 0 {
+		t.Errorf("FilamentWeight(1) = %v, want > 0", got)
+	}
+}
+
+func TestAnalyzeToolChanges(t *testing.T) {
+	a, err := Analyze(strings.NewReader("M83\nT0\nG1 X1 E1\nT1\nG1 X2 E1\nT255\nT0\nG1 X3 E1\n"))
+	if err != nil {
+		t.Fatalf("Analyze() error = %v", err)
+	}
+	if a.ToolChanges != 2 {
+		t.Errorf("ToolChanges = %d, want 2", a.ToolChanges)
+	}
+	if len(a.Filament) != 2 {
+		t.Errorf("Filament = %v, want entries for two tools", a.Filament)
+	}
+}
+
+func TestAnalyze3MF(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("Metadata/plate_2.gcode")
+	if err != nil {
+		t.Fatalf("create zip entry: %v", err)
+	}
+	if _, err := w.Write([]byte(sample)); err != nil {
+		t.Fatalf("write zip entry: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+
+	r := bytes.NewReader(buf.Bytes())
+
+	a, err := Analyze3MF(r, r.Size(), 2)
+	if err != nil {
+		t.Fatalf("Analyze3MF() error = %v", err)
+	}
+	if a.LayerCount() != 2 {
+		t.Errorf("LayerCount() = %d, want 2", a.LayerCount())
+	}
+
+	if _, err := Analyze3MF(r, r.Size(), 1); !errors.Is(err, ErrPlateNotFound) {
+		t.Errorf("Analyze3MF() missing plate error = %v, want %v", err, ErrPlateNotFound)
+	}
+}
@@ -2,14 +2,16 @@ module github.com/torbenconto/bambulabs_api
 
 go 1.26
 
-require github.com/eclipse/paho.mqtt.golang v1.5.1
+require (
+	github.com/eclipse/paho.mqtt.golang v1.5.1
+	github.com/jlaffaye/ftp v0.2.1
+	github.com/mochi-mqtt/server/v2 v2.7.9
+	golang.org/x/net v0.50.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/gorilla/websocket v1.5.3 // indirect
-	github.com/jlaffaye/ftp v0.2.1 // indirect
-	github.com/mochi-mqtt/server/v2 v2.7.9 // indirect
 	github.com/rs/xid v1.6.0 // indirect
-	golang.org/x/net v0.50.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
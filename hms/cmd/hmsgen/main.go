@@ -0,0 +1,278 @@
+// Command hmsgen maintains the HMS database embedded by the hms package.
+//
+// HMS errors update frequently so the default mode scrapes the HMS and device error
+// code pages of the Bambu Lab wiki in every language and rewrites the database file,
+// keeping the version when nothing changed.
+//
+//	https://wiki.bambulab.com/en/hms/home
+//	https://wiki.bambulab.com/en/hms/error-code
+//
+// With -validate the database file is only checked, offline, for well formed codes and
+// canonical formatting, which is what CI runs.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"maps"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/hms"
+	"golang.org/x/net/html"
+)
+
+const (
+	hmsPath    = "/hms/home"
+	devicePath = "/hms/error-code"
+
+	// hmsSkip is the number of leading blockquotes on the HMS page that are notes rather than codes.
+	hmsSkip = 2
+)
+
+func main() {
+	out := flag.String("out", "data/hms.json", "database file to write or validate")
+	base := flag.String("base", "https://wiki.bambulab.com", "wiki base URL")
+	langs := flag.String("lang", "de,fr,es,it,ja,zh", "comma separated languages scraped in addition to English")
+	validate := flag.Bool("validate", false, "only validate the database file")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("hmsgen: ")
+
+	if *validate {
+		if err := validateFile(*out); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := update(*out, *base, strings.Split(*langs, ",")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// validateFile checks that the database at path is valid and written in canonical form.
+func validateFile(path string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	db, err := hms.ParseDatabase(bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	var canonical bytes.Buffer
+	if _, err := db.WriteTo(&canonical); err != nil {
+		return err
+	}
+	if !bytes.Equal(raw, canonical.Bytes()) {
+		return fmt.Errorf("%s: not in canonical form, regenerate it with hmsgen", path)
+	}
+
+	return nil
+}
+
+func update(path, base string, langs []string) error {
+	var prev *hms.Database
+	if f, err := os.Open(path); err == nil {
+		prev, err = hms.ParseDatabase(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	en, err := scrapeLanguage(base, hms.DefaultLanguage)
+	if err != nil {
+		return err
+	}
+
+	db := &hms.Database{Languages: map[string]hms.Messages{hms.DefaultLanguage: en}}
+	for _, lang := range langs {
+		lang = strings.TrimSpace(lang)
+		if lang == "" || lang == hms.DefaultLanguage {
+			continue
+		}
+
+		msgs, err := scrapeLanguage(base, lang)
+		if err != nil {
+			// Translations lag behind English, keep the last known ones rather than failing the update,
+			// but never replace a database with one that silently lost a language.
+			var known hms.Messages
+			ok := false
+			if prev != nil {
+				known, ok = prev.Languages[lang]
+			}
+			if !ok {
+				return fmt.Errorf("%s: %w", lang, err)
+			}
+			log.Printf("%s: %v, keeping previous messages", lang, err)
+			msgs = known
+		}
+		msgs = withoutUntranslated(msgs, en)
+		if len(msgs.HMS) == 0 && len(msgs.Device) == 0 {
+			log.Printf("%s: no translated messages, leaving it out", lang)
+			continue
+		}
+		db.Languages[lang] = msgs
+	}
+
+	if prev != nil && reflect.DeepEqual(prev.Languages, db.Languages) {
+		log.Printf("no changes, keeping version %s", prev.Version)
+		return nil
+	}
+
+	db.Version = time.Now().UTC().Format("2006.01.02")
+	if prev != nil && prev.Version == db.Version {
+		db.Version += "." + time.Now().UTC().Format("150405")
+	}
+
+	if err := db.Validate(); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if _, err := db.WriteTo(&buf); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+
+	log.Printf("wrote %s version %s (%d hms, %d device codes)", path, db.Version, len(en.HMS), len(en.Device))
+	return nil
+}
+
+func scrapeLanguage(base, lang string) (hms.Messages, error) {
+	msgs := hms.Messages{HMS: map[string]string{}, Device: map[string]string{}}
+
+	entries, err := scrape(base+"/"+lang+hmsPath, hmsSkip)
+	if err != nil {
+		return msgs, err
+	}
+	for code, msg := range entries {
+		if e := hms.NewError(code); e != nil {
+			msgs.HMS[hmsKey(*e)] = msg
+		}
+	}
+
+	entries, err = scrape(base+"/"+lang+devicePath, 0)
+	if err != nil {
+		return msgs, err
+	}
+	for code, msg := range entries {
+		if e := hms.ParsePrintError(code); e != nil {
+			msgs.Device[e.GetCode()] = msg
+		}
+	}
+
+	return msgs, nil
+}
+
+// hmsKey formats an HMS code the way the wiki and the database key it.
+func hmsKey(e hms.Error) string {
+	return fmt.Sprintf("HMS_%04X-%04X-%04X-%04X", e.Attribute>>16, e.Attribute&0xffff, e.Code>>16, e.Code&0xffff)
+}
+
+// withoutUntranslated drops messages identical to English, the wiki falls back to English for missing translations and so does the hms package.
+func withoutUntranslated(msgs, en hms.Messages) hms.Messages {
+	out := hms.Messages{HMS: maps.Clone(msgs.HMS), Device: maps.Clone(msgs.Device)}
+	if out.HMS == nil {
+		out.HMS = map[string]string{}
+	}
+	if out.Device == nil {
+		out.Device = map[string]string{}
+	}
+
+	maps.DeleteFunc(out.HMS, func(code, msg string) bool { return en.HMS[code] == msg })
+	maps.DeleteFunc(out.Device, func(code, msg string) bool { return en.Device[code] == msg })
+	return out
+}
+
+// scrape fetches a wiki page and returns the "CODE: message" entries of its blockquotes, skipping the first skip blockquotes.
+func scrape(url string, skip int) (map[string]string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	doc, err := html.Parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", url, err)
+	}
+
+	entries := map[string]string{}
+	for i, bq := range findAll(doc, "blockquote") {
+		if i < skip {
+			continue
+		}
+
+		p := findFirst(bq, "p")
+		if p == nil {
+			continue
+		}
+		strong := findFirst(p, "strong")
+		if strong == nil {
+			continue
+		}
+
+		code, msg, ok := strings.Cut(text(strong), ":")
+		if !ok {
+			continue
+		}
+		code, msg = strings.TrimSpace(code), strings.TrimSpace(msg)
+		if code != "" && msg != "" {
+			entries[code] = msg
+		}
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no codes found on %s", url)
+	}
+	return entries, nil
+}
+
+func findAll(n *html.Node, tag string) []*html.Node {
+	var nodes []*html.Node
+	for d := range n.Descendants() {
+		if d.Type == html.ElementNode && d.Data == tag {
+			nodes = append(nodes, d)
+		}
+	}
+	return nodes
+}
+
+func findFirst(n *html.Node, tag string) *html.Node {
+	for d := range n.Descendants() {
+		if d.Type == html.ElementNode && d.Data == tag {
+			return d
+		}
+	}
+	return nil
+}
+
+func text(n *html.Node) string {
+	var sb strings.Builder
+	for d := range n.Descendants() {
+		if d.Type == html.TextNode {
+			sb.WriteString(d.Data)
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
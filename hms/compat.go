@@ -0,0 +1,13 @@
+package hms
+
+import "maps"
+
+// HmsErrors maps HMS codes to their English message. It is a copy of the embedded database and does not follow [LoadDatabase] or [SetDatabase].
+//
+// Deprecated: use [Lookup], [Error.Error] or [CurrentDatabase].
+var HmsErrors map[string]string
+
+// initCompat fills the deprecated tables from the embedded database.
+func initCompat(db *Database) {
+	HmsErrors = maps.Clone(db.Languages[DefaultLanguage].HMS)
+}
@@ -0,0 +1,154 @@
+package hms
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync/atomic"
+)
+
+// ErrInvalidDatabase is returned when a database fails to decode or validate.
+var ErrInvalidDatabase = errors.New("invalid hms database")
+
+//go:embed data/hms.json
+var embedded []byte
+
+// active is the database used by all lookups, replaced atomically by [SetDatabase].
+var active atomic.Pointer[Database]
+
+func init() {
+	db, err := ParseDatabase(bytes.NewReader(embedded))
+	if err != nil {
+		panic(fmt.Sprintf("hms: embedded database: %v", err))
+	}
+	active.Store(db)
+	initCompat(db)
+}
+
+// Database holds the HMS and device error messages published by Bambu Lab, keyed by language.
+// The English messages are required, other languages may be partial and fall back to English.
+type Database struct {
+	Version   string              `json:"version"`
+	Languages map[string]Messages `json:"languages"`
+}
+
+// Messages maps codes to messages for a single language.
+// HMS keys use the wiki format "HMS_0300-0100-0001-0005" and device error keys the displayed format "0500-4003".
+type Messages struct {
+	HMS    map[string]string `json:"hms"`
+	Device map[string]string `json:"device"`
+}
+
+// ParseDatabase decodes and validates a database in the JSON format of the embedded data/hms.json.
+func ParseDatabase(r io.Reader) (*Database, error) {
+	var db Database
+
+	// Unknown fields are ignored so that database files written by newer versions can be loaded at runtime.
+	if err := json.NewDecoder(r).Decode(&db); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDatabase, err)
+	}
+
+	if err := db.Validate(); err != nil {
+		return nil, err
+	}
+	return &db, nil
+}
+
+// Validate checks that the database has a version, English messages and only well formed codes and messages.
+func (db *Database) Validate() error {
+	if db.Version == "" {
+		return fmt.Errorf("%w: missing version", ErrInvalidDatabase)
+	}
+	if len(db.Languages[DefaultLanguage].HMS) == 0 {
+		return fmt.Errorf("%w: no %s hms messages", ErrInvalidDatabase, DefaultLanguage)
+	}
+
+	for _, lang := range db.LanguageTags() {
+		msgs := db.Languages[lang]
+		// An empty table is what a failed scrape looks like, languages without translations are left out instead.
+		if len(msgs.HMS) == 0 && len(msgs.Device) == 0 {
+			return fmt.Errorf("%w: %s: no messages", ErrInvalidDatabase, lang)
+		}
+
+		for code, msg := range msgs.HMS {
+			if e := NewError(code); e == nil || e.key() != code {
+				return fmt.Errorf("%w: %s: malformed hms code %q", ErrInvalidDatabase, lang, code)
+			}
+			if msg == "" {
+				return fmt.Errorf("%w: %s: empty message for %s", ErrInvalidDatabase, lang, code)
+			}
+		}
+		for code, msg := range msgs.Device {
+			if e := ParsePrintError(code); e == nil || e.GetCode() != code {
+				return fmt.Errorf("%w: %s: malformed device error code %q", ErrInvalidDatabase, lang, code)
+			}
+			if msg == "" {
+				return fmt.Errorf("%w: %s: empty message for %s", ErrInvalidDatabase, lang, code)
+			}
+		}
+	}
+
+	return nil
+}
+
+// LanguageTags returns the languages present in the database in sorted order.
+func (db *Database) LanguageTags() []string {
+	tags := make([]string, 0, len(db.Languages))
+	for lang := range db.Languages {
+		tags = append(tags, lang)
+	}
+	slices.Sort(tags)
+	return tags
+}
+
+// WriteTo encodes the database in the format read by [ParseDatabase], output is stable for unchanged content.
+func (db *Database) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(db); err != nil {
+		return 0, err
+	}
+
+	return buf.WriteTo(w)
+}
+
+// CurrentDatabase returns the database used for lookups, callers must not modify it.
+func CurrentDatabase() *Database {
+	return active.Load()
+}
+
+// SetDatabase replaces the database used for lookups. The current database is kept if db is nil or fails [Database.Validate].
+func SetDatabase(db *Database) error {
+	if db == nil {
+		return fmt.Errorf("%w: nil database", ErrInvalidDatabase)
+	}
+	if err := db.Validate(); err != nil {
+		return err
+	}
+
+	active.Store(db)
+	return nil
+}
+
+// LoadDatabase parses a database from r and makes it the one used for lookups, allowing newer codes to be shipped without rebuilding.
+// The current database is kept if r is invalid.
+func LoadDatabase(r io.Reader) error {
+	db, err := ParseDatabase(r)
+	if err != nil {
+		return err
+	}
+
+	return SetDatabase(db)
+}
+
+// Version returns the version of the database used for lookups.
+func Version() string {
+	return CurrentDatabase().Version
+}
@@ -0,0 +1,135 @@
+package hms
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// withDatabase makes db the current database for the duration of the test.
+func withDatabase(t *testing.T, db *Database) {
+	t.Helper()
+
+	prev := CurrentDatabase()
+	if err := SetDatabase(db); err != nil {
+		t.Fatalf("SetDatabase() error = %v", err)
+	}
+	t.Cleanup(func() { _ = SetDatabase(prev) })
+}
+
+func TestEmbeddedDatabase(t *testing.T) {
+	if Version() == "" {
+		t.Error("Version() is empty")
+	}
+	if err := CurrentDatabase().Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := CurrentDatabase().WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), embedded) {
+		t.Error("embedded database is not in canonical form, run go generate ./hms")
+	}
+}
+
+func TestLoadDatabase(t *testing.T) {
+	withDatabase(t, CurrentDatabase())
+
+	const updated = `{
+  "version": "test",
+  "languages": {
+    "en": {
+      "hms": {"HMS_0300-0100-0001-0005": "updated"},
+      "device": {"0500-4003": "updated device"}
+    }
+  }
+}`
+	if err := LoadDatabase(strings.NewReader(updated)); err != nil {
+		t.Fatalf("LoadDatabase() error = %v", err)
+	}
+
+	if Version() != "test" {
+		t.Errorf("Version() = %q, want %q", Version(), "test")
+	}
+	if got := NewError("HMS_0300_0100_0001_0005").Error(); got != "updated" {
+		t.Errorf("Error() = %q, want the loaded message", got)
+	}
+	if got := NewPrintError(0x05004003).Error(); got != "updated device" {
+		t.Errorf("PrintError.Error() = %q, want the loaded message", got)
+	}
+}
+
+func TestLoadDatabaseInvalid(t *testing.T) {
+	withDatabase(t, CurrentDatabase())
+	version := Version()
+
+	tests := map[string]string{
+		"syntax":       `{"version": `,
+		"no version":   `{"languages": {"en": {"hms": {"HMS_0300-0100-0001-0005": "x"}}}}`,
+		"no english":   `{"version": "v", "languages": {"de": {"hms": {"HMS_0300-0100-0001-0005": "x"}}}}`,
+		"bad hms code": `{"version": "v", "languages": {"en": {"hms": {"HMS_0300_0100_0001_0005": "x"}}}}`,
+		"bad device":   `{"version": "v", "languages": {"en": {"hms": {"HMS_0300-0100-0001-0005": "x"}, "device": {"05004003": "x"}}}}`,
+		"empty":        `{"version": "v", "languages": {"en": {"hms": {"HMS_0300-0100-0001-0005": ""}}}}`,
+		"empty lang":   `{"version": "v", "languages": {"en": {"hms": {"HMS_0300-0100-0001-0005": "x"}}, "de": {"hms": {}, "device": {}}}}`,
+		"no languages": `{"version": "v", "codes": {}}`,
+	}
+	for name, raw := range tests {
+		if err := LoadDatabase(strings.NewReader(raw)); !errors.Is(err, ErrInvalidDatabase) {
+			t.Errorf("%s: LoadDatabase() error = %v, want %v", name, err, ErrInvalidDatabase)
+		}
+	}
+
+	if Version() != version {
+		t.Errorf("Version() = %q after invalid loads, want %q", Version(), version)
+	}
+}
+
+func TestDeprecatedTables(t *testing.T) {
+	en := CurrentDatabase().Languages[DefaultLanguage]
+
+	if got := HmsErrors["HMS_0300-0100-0001-0005"]; got == "" || got != en.HMS["HMS_0300-0100-0001-0005"] {
+		t.Errorf("HmsErrors[HMS_0300-0100-0001-0005] = %q, want the embedded message", got)
+	}
+}
+
+func TestSetDatabaseInvalid(t *testing.T) {
+	current := CurrentDatabase()
+
+	for name, db := range map[string]*Database{
+		"nil":        nil,
+		"empty":      {},
+		"no english": {Version: "v", Languages: map[string]Messages{"de": {HMS: map[string]string{"HMS_0300-0100-0001-0005": "x"}}}},
+	} {
+		if err := SetDatabase(db); !errors.Is(err, ErrInvalidDatabase) {
+			t.Errorf("%s: SetDatabase() error = %v, want %v", name, err, ErrInvalidDatabase)
+		}
+	}
+
+	if CurrentDatabase() != current {
+		t.Error("invalid SetDatabase() replaced the current database")
+	}
+}
+
+func TestLoadDatabaseNewerFields(t *testing.T) {
+	withDatabase(t, CurrentDatabase())
+
+	const newer = `{
+  "version": "next",
+  "generated_by": "a newer hmsgen",
+  "languages": {
+    "en": {
+      "hms": {"HMS_0300-0100-0001-0005": "updated"},
+      "wiki_urls": {}
+    }
+  }
+}`
+	if err := LoadDatabase(strings.NewReader(newer)); err != nil {
+		t.Fatalf("LoadDatabase() error = %v, want fields added by newer versions to be ignored", err)
+	}
+	if Version() != "next" {
+		t.Errorf("Version() = %q, want %q", Version(), "next")
+	}
+}
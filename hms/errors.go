@@ -1,566 +0,0 @@
-// Code generated by hms.py; DO NOT EDIT.
-package hms
-
-var HmsErrors = map[string]string{
-	"HMS_0300-0100-0001-0005": "A heatbed temperature control issue has been detected and the heating module may be damaged. Please power off the device immediately and follow the Wiki to replace the AC board.",
-	"HMS_0300-0100-0001-0006": "The heatbed temperature is abnormal; the sensor may have a short circuit.",
-	"HMS_0300-0100-0001-0007": "The heatbed temperature is abnormal; the sensor may have an open circuit.",
-	"HMS_0300-0100-0001-0008": "An abnormality occurs during the heating process of the heatbed; the heating modules may be broken.",
-	"HMS_0300-0100-0001-000A": "The heatbed temperature control is abnormal; the AC board may be broken.",
-	"HMS_0300-0100-0001-000C": "The heatbed has worked at full load for a long time. The temperature control system may be abnormal.",
-	"HMS_0300-0100-0001-000D": "An abnormality occured in heating modules of heatbed previously. To continue using your printer, please refer to the wiki to troubleshoot.",
-	"HMS_0300-0100-0001-000E": "The power supply voltage does not match the machine; the heatbed has been disabled.",
-	"HMS_0300-0100-0002-000F": "The chamber target temperature is set too high, while the heatbed target temperature is set too low. Heatbed cooling has been skipped. It is recommended to set matching chamber and heatbed temperatures.",
-	"HMS_0300-0100-0003-0008": "The temperature of the heated bed exceeds the limit and automatically adjusts to the limit temperature.",
-	"HMS_0300-0200-0001-0001": "The nozzle temperature is abnormal; the heater may have a short circuit.",
-	"HMS_0300-0200-0001-0002": "The nozzle temperature is abnormal; the heater may have an open circuit.",
-	"HMS_0300-0200-0001-0003": "The nozzle temperature is abnormal; the heater is over temperature.",
-	"HMS_0300-0200-0001-0004": "The right nozzle temperature control is abnormal.  The hotend might have structural damage.",
-	"HMS_0300-0200-0001-0005": "Abnormal nozzle temperature control detected; the heating module may be damaged. Please disconnect the power immediately and stop using the device, and contact customer support for troubleshooting guidance.",
-	"HMS_0300-0200-0001-0006": "The nozzle temperature is abnormal; the sensor may have a short circuit. Please check whether the connector is properly plugged in.",
-	"HMS_0300-0200-0001-0007": "The right nozzle temperature is abnormal; the sensor may have an open circuit.",
-	"HMS_0300-0200-0001-0008": "The extruder nozzle temperature is abnormal and cannot reach the set value. This may be caused by an improperly installed nozzle silicone sock.",
-	"HMS_0300-0200-0001-0009": "Nozzle temperature control is abnormal. The hot end may not be installed. To heat the heating assembly without the hotend, enable Maintenance Mode.",
-	"HMS_0300-0200-0001-000B": "The right extruder nozzle temperature control is abnormal, possibly due to the hotend not being properly installed or a malfunction of the wireless temperature measurement board.",
-	"HMS_0300-0300-0001-0001": "The hotend cooling fan speed is too slow or stopped. It may be stuck or the connector may not be plugged in properly.",
-	"HMS_0300-0300-0002-0002": "The right hotend cooling fan speed is slow. It may be stuck and need cleaning.",
-	"HMS_0300-0400-0002-0001": "The speed of the part cooling fan is too slow or stopped. It may be stuck, or the connector may not be plugged in properly.",
-	"HMS_0300-0600-0001-0001": "Motor-A has an open-circuit. There may be a loose connection, or the motor may have failed.",
-	"HMS_0300-0600-0001-0002": "Motor-A has a short-circuit. It may have failed.",
-	"HMS_0300-0600-0001-0003": "The resistance of Motor-A is abnormal; the motor may have failed.",
-	"HMS_0300-0700-0001-0001": "Motor-B has an open-circuit. The connection may be loose, or the motor may have failed.",
-	"HMS_0300-0700-0001-0002": "Motor-B has a short-circuit. It may have failed.",
-	"HMS_0300-0700-0001-0003": "The resistance of Motor-B is abnormal; the motor may have failed.",
-	"HMS_0300-0800-0001-0001": "The filament currently loaded in the extruder does not support manual extrusion.",
-	"HMS_0300-0800-0001-0002": "Motor-Z has a short-circuit. It may have failed.",
-	"HMS_0300-0800-0001-0003": "The resistance of Motor-Z is abnormal; the motor may have failed.",
-	"HMS_0300-0900-0001-0001": "The extruder servo motor has an open circuit. The connection may be loose, or the motor may have failed.",
-	"HMS_0300-0900-0001-0002": "The extruder servo motor has a short-circuit. It may have failed.",
-	"HMS_0300-0900-0001-0003": "The resistance of the extruder servo motor is abnormal; the motor may have failed.",
-	"HMS_0300-0900-0002-0001": "The extrusion motor is overloaded. The extruder may be clogged or the filament may be stuck in the tool head.",
-	"HMS_0300-0900-0002-0002": "The extrusion resistance is abnormal. The extruder may be clogged or there may be filament stuck in the toolhead.",
-	"HMS_0300-0900-0002-0003": "The extruder is extruding abnormally. It may be clogged, or the filament may be too thin, causing the extruder to slip.",
-	"HMS_0300-0900-0002-0004": "The extrusion resistance is abnormal. The extruder may be clogged or there may be filament stuck in the toolhead, or the externally mounted filament may be tangled.",
-	"HMS_0300-0900-0002-0005": "The extruder is extruding abnormally. It may be clogged, or the filament may be too thin, causing the extruder to slip, or the externally mounted filament may be tangled.",
-	"HMS_0300-0A00-0001-0001": "Heatbed force sensor 1 is too sensitive. It may be stuck between the strain arm and heatbed support, or the adjusting screw may be too tight.",
-	"HMS_0300-0A00-0001-0002": "The signal of heatbed force sensor 1 is weak. The force sensor may be broken or have poor electric connection.",
-	"HMS_0300-0A00-0001-0003": "The signal of heatbed force sensor 1 is too weak. The electronic connection to the sensor may be broken.",
-	"HMS_0300-0A00-0001-0004": "An external disturbance was detected on force sensor 1. The heatbed plate may have touched something outside the heatbed.",
-	"HMS_0300-0A00-0001-0005": "Force sensor 1 detected unexpected continuous force. The heatbed may be stuck, or the analog front end may be broken.",
-	"HMS_0300-0D00-0001-0003": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-0004": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-0005": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-0006": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-0007": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-0008": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-0009": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-000A": "The build plate is not placed properly. Please adjust it.",
-	"HMS_0300-0D00-0001-000B": "The Z axis motor seems to be stuck when moving. Please check if there is any foreign matter on the Z sliders or Z timing belt wheels.",
-	"HMS_0300-0D00-0001-000C": "The heatbed leveling data is abnormal. Please check whether there are any foreign objects on the heatbed and Z slider. If so, please remove them and try again.",
-	"HMS_0300-0D00-0002-0001": "Heatbed homing abnormal: there may be a bulge on the heatbed or the nozzle tip may not be clean.",
-	"HMS_0300-0D00-0002-0003": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0D00-0002-0004": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0D00-0002-0005": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0D00-0002-0006": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0D00-0002-0007": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0D00-0002-0008": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0D00-0002-0009": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0D00-0002-000A": "The build plate may not be properly placed. If this message appears repeatedly, please check the Wiki for more explanations.",
-	"HMS_0300-0F00-0001-0001": "Abnormal accelerometer data detected. Please try restarting the printer.",
-	"HMS_0300-1000-0002-0001": "The resonance frequency of the X axis is low. The timing belt may be loose.",
-	"HMS_0300-1000-0002-0002": "The resonance frequency of the X-axis differs significantly from the last calibration.  Please clean the X-axis linear rail and conduct a calibration after printing.",
-	"HMS_0300-1001-0002-0003": "X-axis vibration compensation data is missing or invalid. Please re-run the calibration in the \"Maintenance &gt; Calibration\" page.",
-	"HMS_0300-1100-0002-0001": "The resonance frequency of the Y axis is low. The timing belt may be loose.",
-	"HMS_0300-1100-0002-0002": "The resonance frequency of the Y-axis differs greatly from the last calibration. Please clean the Y-axis liner rail and conduct a calibration after printing.",
-	"HMS_0300-1101-0002-0003": "Y-axis vibration compensation data is missing or invalid. Please re-run the calibration in the \"Maintenance &gt; Calibration\" page.",
-	"HMS_0300-1200-0002-0001": "The front cover of the toolhead fell off.",
-	"HMS_0300-1300-0001-0001": "The current sensor of Motor-A is abnormal. This may be caused by a failure of the hardware sampling circuit.",
-	"HMS_0300-1400-0001-0001": "The current sensor of Motor-B is abnormal. This may be caused by a failure of the hardware sampling circuit.",
-	"HMS_0300-1500-0001-0001": "The current sensor of Motor-Z is abnormal. This may be caused by a failure of the hardware sampling circuit.",
-	"HMS_0300-1600-0001-0001": "The extruder servo motor's current sensor is abnormal. A failure of the hardware sampling circuit may cause this.",
-	"HMS_0300-1700-0001-0001": "The hotend cooling fan speed is too slow or stopped. It may be stuck or the connector may not be plugged in properly.",
-	"HMS_0300-1700-0002-0002": "The hotend cooling fan speed is slow. It may be stuck and need cleaning.",
-	"HMS_0300-1800-0001-0001": "The extruder eddy current sensor value is too low. The nozzle may not be installed.",
-	"HMS_0300-1800-0001-0002": "The sensitivity of the extruder eddy current sensor is low; the nozzle may not be installed correctly.",
-	"HMS_0300-1800-0001-0003": "The extruder eddy current sensor is not responding. The MC\u2013TH communication link may be broken, or the sensor may be damaged.",
-	"HMS_0300-1800-0001-0004": "The extruder eddy current sensor signal is abnormal; the sensor is probably broken.",
-	"HMS_0300-1800-0001-0005": "The Z\u2011axis motor became stuck during movement. Please check for foreign objects on the Z\u2011axis sliders or timing belt pulleys, and check whether the extruder eddy current sensor is abnormal.",
-	"HMS_0300-1800-0001-0006": "The heatbed leveling data is abnormal. Please check whether there are any foreign objects on the heatbed and Z slider. If so, please remove them and try again.",
-	"HMS_0300-1800-0001-0007": "The frequency of the extruder eddy current sensor is too high. The sensor may be damaged, or the nozzle heat sink may be too close to the sensor.",
-	"HMS_0300-1800-0001-0008": "The nozzle touches the heatbed abnormally. Please check whether there is filament residue on the nozzle or foreign matter where the nozzle touches the bed.",
-	"HMS_0300-1800-0001-000B": "Nozzle presence detection failed: nozzle not installed or improperly installed.",
-	"HMS_0300-1800-0001-000C": "Abnormal signal jump detected in the extruder eddy current sensor, which may be caused by poor contact or a defective sensor.",
-	"HMS_0300-1800-0001-000D": "Nozzle clumping detection calibration failed. Excessive force detected on the nozzle. Please ensure the nozzle is installed correctly.",
-	"HMS_0300-1800-0003-0009": "Performing initial High-temperature Bed Leveling.",
-	"HMS_0300-1900-0001-0001": "The eddy current sensor on Y-axis is not available; the wire is probably broken.",
-	"HMS_0300-1900-0002-0002": "The sensitivity of the Y-axis eddy current sensor is too low. Please remove any foreign objects on the Y-axis linear rail.",
-	"HMS_0300-1A00-0002-0001": "The nozzle is covered with filament, or the build plate is crooked.",
-	"HMS_0300-1A00-0002-0002": "The nozzle is clogged with filament.",
-	"HMS_0300-1A00-0002-0003": "The nozzle clumping sensor is malfunctioning; Please refer to the Wiki for troubleshooting.",
-	"HMS_0300-1B00-0001-0001": "The signal of the heatbed acceleration sensor is weak. The sensor may have fallen off or been damaged.",
-	"HMS_0300-1B00-0001-0002": "External disturbance was detected on the heatbed acceleeration sensor. The sensor signal wire may not be affixed.",
-	"HMS_0300-1B00-0001-0003": "The heatbed acceleration sensor detected unexpected continuous force. The sensor may be stuck, or the analog front end may be broken.",
-	"HMS_0300-1C00-0001-0001": "The extrusion motor driver is abnormal. The MOSFET may have a short circuit.",
-	"HMS_0300-1D00-0001-0001": "The position sensor of extrusion motor is abnormal. The connection to the sensor may be loose.",
-	"HMS_0300-1D00-0001-000A": "Extruder motor overload detected. The motor may be faulty.",
-	"HMS_0300-1E00-0001-0001": "The left nozzle temperature is abnormal; the heater may have a short circuit.",
-	"HMS_0300-1E00-0001-0002": "The left nozzle temperature is abnormal; the heater may have an open circuit.",
-	"HMS_0300-1E00-0001-0003": "The left nozzle temperature is abnormal; the heater is overheated.",
-	"HMS_0300-1E00-0001-0006": "The left nozzle temperature is abnormal; the sensor may have a short circuit. Please check whether the connector is properly plugged in.",
-	"HMS_0300-1E00-0001-0007": "The left nozzle temperature is abnormal; the sensor may have an open circuit.",
-	"HMS_0300-1E00-0001-0009": "The left nozzle temperature control is abnormal; the hot end may not be installed. If you want to heat the hot end without it being installed, please turn on maintenance mode.",
-	"HMS_0300-2000-0001-0001": "X-axis homing abnormal: please check if the toolhead is stuck or if the resistance on the X-axis linear rail is too high.",
-	"HMS_0300-2000-0001-0002": "Y axis homing abnormal: Please check if the heatbed is stuck or if the motion resistance is too high.",
-	"HMS_0300-2000-0001-0003": "X axis homing abnormal: the timing belt may be loose.",
-	"HMS_0300-2000-0001-0004": "Y axis homing abnormal: the timing belt may be loose.",
-	"HMS_0300-2100-0001-0001": "Auxiliary extruder motor open circuit, possibly due to a loose connection or motor failure.",
-	"HMS_0300-2500-0001-0001": "Low signal frequency detected on the right extruder eddy current sensor. The nozzle may not be installed, or the nozzle heat sink may be too far away from the sensor.",
-	"HMS_0300-2500-0001-0002": "Low sensitivity detected in the right extruder eddy current sensor. Check the nozzle installation.",
-	"HMS_0300-2500-0001-0003": "Unable to read data from the right extruder eddy current sensor; there may be a communication break or sensor damage.",
-	"HMS_0300-2500-0001-0004": "The eddy current sensor signal of the right extruder is abnormal. The sensor may be damaged, or the MC-TH communication may be abnormal.",
-	"HMS_0300-2500-0001-0005": "Z-axis motor rotation is obstructed; please check if foreign objects are stuck in the Z slider or Z timing pulley. Also, ensure the build plate is placed correctly to avoid collisions with surrounding structures.",
-	"HMS_0300-2500-0001-0007": "High signal frequency detected on the right extruder eddy current sensor. The sensor may be damaged, or the nozzle heat sink may be too close to the sensor.",
-	"HMS_0300-2500-0001-0008": "The right nozzle touches the heating bed abnormally. Please check whether there is filament residue on the nozzle, foreign matter at the point where the nozzle contacts the bed, or severe deformation of the flow blocker.",
-	"HMS_0300-2500-0001-000A": "Nozzle offset calibration failed. Filament sticks to the nozzle, which may affect print quality. Please clean the nozzle and try again.",
-	"HMS_0300-2500-0001-000B": "Nozzle presence detection failed: Right extruder nozzle not installed or improperly installed.",
-	"HMS_0300-2500-0001-000C": "An anomalous jump in the right extruder eddy current sensor data has been detected, potentially caused by poor sensor contact or a faulty sensor.",
-	"HMS_0300-2600-0001-0001": "Low signal frequency detected on the left extruder eddy current sensor. The sensor may be installed too far, or the sensor may be loose.",
-	"HMS_0300-2600-0001-0002": "Left extruder eddy current sensor sensitivity is low. Check sensor installation.",
-	"HMS_0300-2600-0001-0007": "High signal frequency detected on the left extruder eddy current sensor. The sensor may be installed too close, or the sensor may be loose.",
-	"HMS_0300-2600-0001-000B": "Nozzle presence detection failed: Left extruder nozzle not installed or improperly installed.",
-	"HMS_0300-2700-0001-0001": "The nozzle offset calibration sensor frequency is too low. The sensor may be damaged.",
-	"HMS_0300-2700-0001-0002": "Too many attempts at nozzle offset calibration, possibly due to purged filament between the nozzle and the heated base, or incorrect installation of the nozzle. Please inspect and retry.",
-	"HMS_0300-2700-0001-0004": "The signal of the nozzle offset calibration sensor is abnormal. The sensor may be damaged, or the wiring may not be connected properly.",
-	"HMS_0300-2700-0001-0006": "The nozzle offset calibration shows significant deviation, possibly due to incorrect installation of the nozzle or heating base. Please inspect and retry.",
-	"HMS_0300-2700-0001-0007": "The nozzle offset calibration sensor frequency is too high. The sensor may be damaged.",
-	"HMS_0300-2700-0001-0008": "The nozzle offset calibration sensor signal is too weak. It may be that the nozzle is sticky or the nozzle does not move above the sensor during calibration, causing the distance between the nozzle and the sensor to be too far.",
-	"HMS_0300-2800-0001-0001": "The data of the force sensor of the Cutting Module is abnormal. The magnet on the Tool Holder may fall off or the force sensor may be damaged.",
-	"HMS_0300-2800-0001-0003": "Communication failure between cutting module and toolhead during Z-axis homing. Please check if the cutting module signal cable is loose or broken or verify if the force sensor coil is intact.",
-	"HMS_0300-2800-0001-0004": "The force sensor of the Cutting Module is abnormal. The force sensor cable may be disconnected or the force sensor is damaged.",
-	"HMS_0300-2800-0001-0005": "Z-axis homing failed in cutting mode. Please check if there are any foreign objects in the Z-axis slider and Z-axis synchronous pulley.",
-	"HMS_0300-2800-0001-0007": "Communication failure between the Cutting Module and toolhead module. Please check if the Cutting module signal cable is loose or broken. It could also be due to a broken force sensor coil.",
-	"HMS_0300-2900-0001-0001": "Vision encoder patterns can not be recognized; possible reasons include vision encoder pattern distortion, light overexposure, and plate misplacement.",
-	"HMS_0300-2B00-0002-0001": "Air-door calibration failed. Please check if there is any foreign object blocking the damper.",
-	"HMS_0300-2D00-0001-0006": "Heatbed leveling failed. Please remove any debris from the bed and retry. If the issue persists, refer to the Wiki for manual bed leveling. If the X-axis rail is visibly tilted, refer to the Wiki for repair.",
-	"HMS_0300-2D00-0003-0001": "Heatbed warming in progress to improve first-layer print quality. Please wait.",
-	"HMS_0300-2D00-0003-0009": "Performing initial High-temperature Bed Leveling.",
-	"HMS_0300-2E00-0003-0001": "The motor noise cancellation feature needs to be updated; please recalibrate.",
-	"HMS_0300-3100-0001-0001": "The Part Cooling Fan speed is too slow or stopped. It may be stuck, or the connector may not be plugged in properly.",
-	"HMS_0300-3100-0002-0002": "The Part Cooling Fan speed is slow. It may be stuck and need cleaning.",
-	"HMS_0300-3200-0001-0001": "The Auxiliary Part Cooling Fan speed is too slow or stopped. It may be stuck, or the connector may not be plugged in properly.",
-	"HMS_0300-3200-0001-0002": "The Right Side (Auxiliary Component Cooling-Filtration) Fan speed is slow. It may be stuck and need cleaning.",
-	"HMS_0300-3500-0001-0001": "The MC module cooling fan speed is too slow or stopped. It may be stuck, or the connector may not be plugged in properly.",
-	"HMS_0300-3500-0002-0002": "The MC module cooling fan speed is slow. It may be stuck and need cleaning.",
-	"HMS_0300-3600-0001-0001": "The Chamber Heat Circulation Fan speed is too slow or stopped. It may be stuck, or the connector may not be plugged in properly.",
-	"HMS_0300-3A00-0001-0001": "The Right Side (Auxiliary Component Cooling-Filtration) Fan speed is too slow or stopped. It may be stuck, or the connector may not be plugged in properly.",
-	"HMS_0300-3A00-0002-0002": "The speed of Auxiliary Part Cooling Fan - Left is slow. It may be stuck and need cleaning.",
-	"HMS_0300-4000-0002-0001": "Data transmission over the serial port is abnormal; the software system may be faulty.",
-	"HMS_0300-4100-0001-0001": "The system voltage is unstable. Triggering the power failure protection function.",
-	"HMS_0300-9000-0001-0001": "Chamber heating failed. The heater may not be blowing hot air.",
-	"HMS_0300-9000-0001-0002": "Chamber heating failed. Possible causes: the chamber is not fully enclosed, ambient temperature is too low, or the power supply heat dissipation vent is blocked.",
-	"HMS_0300-9000-0001-0003": "Chamber heating failed. The power supply temperature may be too high.",
-	"HMS_0300-9000-0001-0004": "Chamber heating failed. The speed of the heating fan is too low.",
-	"HMS_0300-9000-0001-0005": "Chamber heating failed. The thermal resistance is too high.",
-	"HMS_0300-9000-0001-0010": "The communication of chamber temperature controller is abnormal.",
-	"HMS_0300-9100-0001-0001": "The temperature of chamber heater 1 is abnormal. The heater may have a short circuit.",
-	"HMS_0300-9100-0001-0002": "The temperature of chamber heater 1 is abnormal. The heater may have an open circuit or the thermal fuse may have burned out.",
-	"HMS_0300-9100-0001-0003": "The temperature of chamber heater 1 is abnormal. The heater is over temperature.",
-	"HMS_0300-9100-0001-0006": "The temperature of chamber heater 1 is abnormal. The sensor may have a short circuit.",
-	"HMS_0300-9100-0001-0007": "The temperature of chamber heater 1 is abnormal. The sensor may have an open circuit.",
-	"HMS_0300-9100-0001-0008": "The chamber heater 1 failed to reach the target temperature.",
-	"HMS_0300-9100-0001-000A": "The temperature of chamber heater 1 is abnormal. The AC board may be broken.",
-	"HMS_0300-9100-0001-000C": "The chamber heater 1 has worked at full load for a long time. The temperature control system may be abnormal.",
-	"HMS_0300-9100-0001-000E": "The power supply voltage does not match the machine; chamber heater 1 has been disabled.",
-	"HMS_0300-9200-0001-0002": "The temperature of chamber heater is abnormal. The heater may have an open circuit or the thermal fuse may be in effect.",
-	"HMS_0300-9300-0001-0001": "Chamber temperature is abnormal. The chamber heater's temperature sensor may have a short circuit.",
-	"HMS_0300-9300-0001-0002": "Chamber temperature is abnormal. The chamber heater's temperature sensor may have an open circuit.",
-	"HMS_0300-9300-0001-0003": "Chamber temperature is abnormal. The chamber heater's temperature sensor at the air outlet may have a short circuit.",
-	"HMS_0300-9300-0001-0004": "Chamber temperature is abnormal. The chamber heater's temperature sensor at the air outlet may have an open circuit.",
-	"HMS_0300-9300-0001-0005": "Chamber temperature is abnormal. The chamber heater's temperature sensor at the air inlet may have a short circuit.",
-	"HMS_0300-9300-0001-0006": "Chamber temperature is abnormal. The chamber heater's temperature sensor at the air inlet may have an open circuit.",
-	"HMS_0300-9300-0001-0007": "Chamber temperature is abnormal. The temperature sensor at the power supply may have a short circuit.",
-	"HMS_0300-9300-0001-0008": "Chamber temperature is abnormal. The temperature sensor at power supply may have an open circuit.",
-	"HMS_0300-9400-0002-0003": "Chamber failed to reach the desired temperature. The machine will stop waiting for the chamber temperature.",
-	"HMS_0300-9400-0003-0001": "Chamber cooling may be too slow. You can open the front door or top cover to help cooling if the air in the chamber is non-toxic.",
-	"HMS_0300-9500-0001-0003": "Laser Module overheating",
-	"HMS_0300-9500-0001-0005": "The Laser Module communication is abnormal; please check the connector.",
-	"HMS_0300-9500-0001-0006": "Laser Module not detected: the module may have fallen off, or the quick-release lever may not be locked.",
-	"HMS_0300-9500-0001-000A": "The ambient temperature sensor at the bottom of the laser module is abnormal; the sensor has an open circuit.",
-	"HMS_0300-9600-0001-0001": "The front door seems to be open; the task has been paused.",
-	"HMS_0300-9600-0001-0002": "The front door Hall sensor (Upper) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9600-0001-0003": "The front door Hall sensor (Lower) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9700-0001-0001": "The top cover seems to be open; the task has been paused",
-	"HMS_0300-9700-0001-0002": "The top cover Hall sensor (Front Right) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9700-0001-0003": "The top cover Hall sensor (Rear Left) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9700-0001-0004": "The Top Laser Protection Plate is not detected. Please install it according to the Wiki and re-initiate the task.",
-	"HMS_0300-9800-0001-0002": "The left side window Hall sensor (Upper) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9800-0001-0003": "The left side window Hall sensor (Lower) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9900-0001-0002": "The right side window Hall sensor (Lower) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9900-0001-0003": "The right side window Hall sensor (Upper) is abnormal; please check whether the connection wire is loose.",
-	"HMS_0300-9D00-0002-0001": "The engrave laser focal point XY calibration has failed. Please clean up the Laser Homing Area on the Laser Platform, and re-run the Laser Module Mount Calibration.",
-	"HMS_0300-A100-0001-0001": "The ambient temperature is too high. Please lower it before printing to avoid nozzle clogging.",
-	"HMS_0300-A100-0002-0003": "The extruder temperature sensor is damaged. Please replace the temperature sensor.",
-	"HMS_0300-A200-0001-0001": "MC module temperature is too high, possibly because of high chamber temperature of the printer. You can try lowering the environmental temperature before use.",
-	"HMS_0300-A600-0001-0001": "The Toolhead Enhanced Cooling Fan is not properly installed; it may not be securely fastened or may have fallen off.",
-	"HMS_0300-A600-0001-0002": "The Toolhead Enhanced Cooling Fan has lost communication; please check the connector.",
-	"HMS_0300-A800-0001-0001": "AMS power supply abnormality, possibly due to AMS damage or a short circuit in the AMS interface, or too many AMS connections. Please check if it is correctly connected.",
-	"HMS_0300-AB00-0001-0001": "The right hotend heatbreak temperature is too high, which may cause clogging. Please open the printer\u2019s top cover and front door to reduce the chamber temperature, or lower the ambient temperature.",
-	"HMS_0300-AB00-0002-0002": "The right hotend heatbreak temperature is relatively high, which may cause nozzle clogging. Please open the printer\u2019s top cover and front door to reduce the chamber temperature, or lower the ambient temperature.",
-	"HMS_0300-AB00-0002-0003": "The temperature sensor on the right hotend heatbreak is abnormal. To prevent nozzle clogs, the cooling fan will continue running at high speed. You can troubleshoot the sensor issue when the print is idle.",
-	"HMS_0300-B800-0001-0002": "Toolhead communication lost and abnormal current detected. The toolhead power has been cut off. Please restart the device. If the issue persists, contact customer support.",
-	"HMS_0300-C000-0001-0001": "The chamber temperature switching flap operation failed. It may get stuck.",
-	"HMS_0300-C000-0001-0002": "Filter Switch Flap air door malfunction: it may be stuck.",
-	"HMS_0300-C000-0001-0003": "Automatic Top Vent air door malfunction: it may be stuck.",
-	"HMS_0300-C100-0001-0001": "Airflow System failed to activate cooling mode; please check the air door status.",
-	"HMS_0300-C100-0001-0002": "Airflow System failed to activate heating mode; please check the air door status.",
-	"HMS_0300-C100-0001-0003": "Airflow System failed to activate laser mode; please check the air door status.",
-	"HMS_0300-C200-0001-0001": "Hall sensor of Active Chamber Exhaust malfunction: please check if the wiring is loose.",
-	"HMS_0300-C200-0001-0002": "Hall sensor of Filter Switch Flap malfunction: please check if the wiring is loose.",
-	"HMS_0300-C300-0001-0001": "Current sensor of Active Chamber Exhaust malfunction: this may be due to an open circuit or a hardware sampling circuit fault.",
-	"HMS_0300-C300-0001-0002": "Current sensor of Filter Switch Flap malfunction: this may be due to an open circuit or a hardware sampling circuit fault.",
-	"HMS_0300-D000-0001-0001": "The cutting module base has fallen off; please reinstall it.",
-	"HMS_0300-D000-0001-0003": "The cutting module cable has come loose; please check the cable connection.",
-	"HMS_0300-DA00-0002-0001": "Laser module detected. Please remove the hotend from the hotend rack to prevent laser processing debris from affecting printing performance.",
-	"HMS_0300-E000-0001-0001": "Hotend holder motor is disconnected or has an open-circuit fault. Please check the motor\u2019s flat cable connection.",
-	"HMS_0300-E000-0001-0002": "Inter-phase short circuit on hotend holder motor. If restarting doesn\u2019t resolve the issue, please contact support.",
-	"HMS_0300-E000-0001-0003": "Hotend holder motor has abnormal or unbalanced three-phase resistance. If restarting doesn\u2019t help, please contact support.",
-	"HMS_0300-E000-0001-0004": "Hotend holder motor is stalled. Please check for foreign objects or collisions with the toolhead.",
-	"HMS_0300-E100-0001-0001": "Current sensor fault on hotend holder motor. This may be caused by a sampling circuit failure. If restarting the printer doesn\u2019t resolve the issue, please contact customer support.",
-	"HMS_0300-E200-0002-0001": "Communication error between the hotend holder motor and the position sensor. If restarting fails, please contact customer support.",
-	"HMS_0300-E300-0001-0001": "The MOS tubes of the Hotend Holder motor drive board are short-circuited. If restarting the printer does not resolve the problem, please contact customer support.",
-	"HMS_0300-E300-0001-0002": "MC communication with Rack Control Board failed. Please check if the connection cable is properly plugged in.",
-	"HMS_0500-0100-0002-0001": "The media pipeline is malfunctioning. Please restart the printer. If multiple attempts fail, please contact customer support.",
-	"HMS_0500-0100-0002-0002": "Live View camera is not connected. Please check the hardware and cable connections.",
-	"HMS_0500-0100-0002-0003": "",
-	"HMS_0500-0100-0003-0005": "The Micro SD card is in Read-Only mode. Video recording and Timelapse recording cannot be performed. Please refer to the Wiki for assistance.",
-	"HMS_0500-0100-0003-0006": "Unformatted MicroSD Card: please format it.",
-	"HMS_0500-0200-0002-0001": "Failed to connect to the internet. Please check the network connection.",
-	"HMS_0500-0200-0002-0002": "Device login failed; please check your account information.",
-	"HMS_0500-0200-0002-0003": "Failed to connect to the internet; please check the network connection.",
-	"HMS_0500-0200-0002-0004": "Unauthorized user: please check your account information.",
-	"HMS_0500-0200-0002-0005": "Failed to connect to the internet; please check the network connection.",
-	"HMS_0500-0200-0002-0006": "Streaming function error. Please check the network and try again. You can restart or update the printer if the issue persists.",
-	"HMS_0500-0200-0002-0008": "Time synchronization failed",
-	"HMS_0500-0300-0001-0001": "The MC module is malfunctioning; please restart the device or check device cable connection.",
-	"HMS_0500-0300-0001-0002": "The toolhead is malfunctioning. Please restart the device.",
-	"HMS_0500-0300-0001-0003": "The AMS module is malfunctioning. Please restart the device.",
-	"HMS_0500-0300-0001-0004": "The Filament Buffer module is malfunctioning. Please restart the device.",
-	"HMS_0500-0300-0001-0007": "The Toolhead expansion module is malfunctioning. Please power off, check the connection, and restart the device.",
-	"HMS_0500-0300-0001-000A": "System state is abnormal; please restore to factory settings.",
-	"HMS_0500-0300-0001-000B": "The screen is malfunctioning; please restart the device.",
-	"HMS_0500-0300-0001-000C": "The MC motor controller module is malfunctioning. Please power off, check the connection, and restart the device.",
-	"HMS_0500-0300-0001-000D": "The induction hotend rack is malfunctioning. Please power off, check the connection, and restart the device.",
-	"HMS_0500-0300-0001-0021": "Hardware incompatible; please check the Micro Lidar.",
-	"HMS_0500-0300-0002-000C": "Wireless hardware error: please turn off/on WiFi or restart the device.",
-	"HMS_0500-0300-0002-000E": "Some modules are incompatible with the printer's firmware version, which may affect use. Please go to the \"Firmware\" page to update after connected to the internet, or you may update offline according to wiki.",
-	"HMS_0500-0300-0002-0020": "USB flash drive capacity is insufficient to cache print files.",
-	"HMS_0500-0400-0001-0001": "Failed to download print job; please check your network connection.",
-	"HMS_0500-0400-0001-0002": "Failed to report print state; please check your network connection.",
-	"HMS_0500-0400-0001-0003": "The content of print file is unreadable; please resend the print job.",
-	"HMS_0500-0400-0001-0004": "The print file is unauthorized.",
-	"HMS_0500-0400-0001-0006": "Failed to resume previous print.",
-	"HMS_0500-0400-0001-0025": "Abnormal connection between AMS/AMS lite and the device is detected. Please refer to the Wiki for adjustment.",
-	"HMS_0500-0400-0001-0044": "The firmware of AMS A does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0400-0001-0046": "The firmware of Laser Module does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0400-0001-0047": "The firmware of Air Pump does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0400-0001-0048": "The firmware of Cutting Module does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0400-0001-0049": "Communication error detected with AMS, AMS lite or AMS HT. Please reconnect the module cable or restart the printer when it is idle.",
-	"HMS_0500-0400-0001-0051": "Emergency Stop Button is not in the right position. Please follow the Wiki to install it.",
-	"HMS_0500-0400-0001-0052": "Safety Key is not inserted. Please follow the Wiki to install it.",
-	"HMS_0500-0400-0002-0007": "The bed temperature exceeds the filament's vitrification temperature, which may cause a nozzle clog. Please keep the front door of the printer open or lower the bed temperature.",
-	"HMS_0500-0400-0002-0030": "The BirdsEye Camera is not installed. Please power off printer and then install the camera.",
-	"HMS_0500-0400-0002-0031": "Before using a Laser/Cutting Module, the pose of the BirdsEye Camera needs to be determined. Please complete the setup to calibrate the camera.",
-	"HMS_0500-0400-0002-0032": "Please slide in Laser Module and lock the quick-release lever.",
-	"HMS_0500-0400-0002-0033": "Please plug in the module connector.",
-	"HMS_0500-0400-0002-0034": "Laser module detected for the first time. Complete the setup (~4 min) before use for precise cutting and engraving. Also, ensure the H2.0 screw at the back securing the air pump is removed.",
-	"HMS_0500-0400-0002-0035": "The Laser Module needs calibration to get the focus position. Please perform mounting calibration before use. (about 2 minutes)",
-	"HMS_0500-0400-0002-0037": "The Cutting Module needs calibration to get the tool position. Please perform the mounting calibration before use. (about 2-4 minutes)",
-	"HMS_0500-0400-0002-0038": "Please slide in cutting module and fasten the quick release lever. If already installed, the module might not be properly aligned. Please try reinstalling it.",
-	"HMS_0500-0400-0002-0041": "The laser module has been used for a long time. Please clean it promptly to avoid affecting laser processing.",
-	"HMS_0500-0400-0002-0042": "The Live View Camera is dirty or obstructed; please clean it and continue.",
-	"HMS_0500-0400-0002-0043": "The Toolhead Camera is dirty or obstructed; please clean it and continue.",
-	"HMS_0500-0400-0002-0050": "Laser Safety Window is not installed.",
-	"HMS_0500-0500-0001-0007": "MQTT Command verification failed. Please update Studio (including the network plugin) or Handy to the latest version, then restart the software and try again.",
-	"HMS_0500-0500-0001-000E": "Laser Module firmware does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0500-0001-000F": "The accessory firmware does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0500-0001-0010": "The firmware of AMS A does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0500-0001-0011": "The Air Pump firmware does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0500-0001-0012": "The Cutting Module firmware does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0500-0500-0001-0014": "AMS A certification failed. Please reconnect the cable or restart the printer.",
-	"HMS_0500-0500-0001-0015": "Air Pump certification failed. Please reconnect the cable or restart the printer.",
-	"HMS_0500-0500-0001-001A": "Filament Track Switch certification failed. Please reconnect the cable or restart the printer.",
-	"HMS_0500-0500-0001-0020": "Hotend 1 authentication failed. Please switch the hotend or restart the printer.",
-	"HMS_0500-0600-0002-0001": "The Toolhead Camera is not in place; please check the hardware connection.",
-	"HMS_0500-0600-0002-0002": "The Nozzle Camera is not in place; please check the hardware connection.",
-	"HMS_0500-0600-0002-0004": "The Live View camera is not in place; please check the hardware connection.",
-	"HMS_0500-0600-0002-0031": "ToolHead Camera is not connected. Please check the hardware and cable connections.",
-	"HMS_0500-0600-0002-0032": "Nozzle Camera is not connected. Please check the hardware and cable connections.",
-	"HMS_0500-0600-0002-0034": "Live View camera is not connected. Please check the hardware and cable connections.",
-	"HMS_0501-0400-0003-0002": "Threaded rods need lubrication now.",
-	"HMS_0501-0400-0003-0005": "Please clean and lubricate the Induction Hotend Latch and the linear rods of the Hotend Change System.",
-	"HMS_0501-0400-0003-0006": "The Induction Hotend Latch may be worn. To ensure proper induction hotend switching, please replace the  Induction Hotend Latch and clean the rods of Induction Hotend Rack.",
-	"HMS_0580-0400-0001-0045": "The firmware of AMS-HT A does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0580-0500-0001-0010": "The firmware of AMS-HT A does not match the printer. Please upgrade it on the \"Firmware\" page.",
-	"HMS_0580-0500-0001-0017": "AMS-HT A certification failed. Please reconnect the cable or restart the printer.",
-	"HMS_0700-0100-0001-0001": "The AMS A assist motor has slipped. The extrusion wheel may be worn down, or the filament may be too thin.",
-	"HMS_0700-0100-0001-0003": "The AMS A assist motor torque control is malfunctioning. The current sensor may be faulty.",
-	"HMS_0700-0100-0001-0004": "The AMS A assist motor speed control is malfunctioning. The speed sensor may be faulty.",
-	"HMS_0700-0100-0001-0005": "AMS A The current sensor of assist motor may be faulty.",
-	"HMS_0700-0100-0002-0002": "The AMS A assist motor is overloaded. The filament may be tangled or stuck.",
-	"HMS_0700-0100-0002-0006": "AMS A The assist motor three-phase wires are not connected. The assist motor connector may have poor contact.",
-	"HMS_0700-0100-0002-0007": "AMS A The assist motor encoder wires are not connected. The assist motor connector may have poor contact.",
-	"HMS_0700-0100-0002-0008": "AMS A The assist motor phase winding has an open circuit. The assist motor may be faulty.",
-	"HMS_0700-0100-0002-0009": "AMS A The assist motor has unbalanced tree-phase resistaance. The assist motor may be faulty.",
-	"HMS_0700-0100-0002-0010": "AMS A The assist motor resistance is abnormal. The assist motor may be faulty.",
-	"HMS_0700-0100-0002-0011": "AMS A The motor assist parameter is lost. Please pull out the filament from the filament hub and then restart the AMS.",
-	"HMS_0700-0200-0001-0001": "AMS A Filament speed and length error: The filament odometry may be faulty.",
-	"HMS_0700-0200-0002-0002": "AMS A The odometer has no signal. The odometer connector may have poor contact.",
-	"HMS_0700-0200-0002-0003": "AMS A filament odometer speed signal is abnormal. If you hear unusual noises or the filament fails to unload from the Auxiliary Extruder, please replace the filament odometer.",
-	"HMS_0700-1000-0001-0001": "The AMS A slot 1 motor has slipped. The extrusion wheel may be malfunctioning, or the filament may be too thin.",
-	"HMS_0700-1000-0001-0003": "The AMS A slot 1 motor torque control is malfunctioning. The current sensor may be faulty.",
-	"HMS_0700-1000-0002-0002": "The AMS A slot 1 motor is overloaded. The filament may be tangled or stuck.",
-	"HMS_0700-1000-0002-0004": "AMS A The brushed motor 1 has no signal, which may be due to poor contact in the motor connector or a motor fault.",
-	"HMS_0700-2000-0001-0081": "Failed to read the filament information from AMS A slot 1. The AMS main board may be malfunctioning.",
-	"HMS_0700-2000-0001-0083": "Failed to read the filament information from AMS A slot 1. The RFID tag may be damaged.",
-	"HMS_0700-2000-0001-0084": "Failed to read the filament information from AMS A slot 1. The RFID tag may be damaged or positioned at the edge of the RFID detection device. Please remove 5cm filament and try again.",
-	"HMS_0700-2000-0001-0086": "Failed to read the filament information from AMS A slot 1. The RFID tag cannot rotate due to a jam during the filament loading or unloading. Please pull out the filament and try again.",
-	"HMS_0700-2000-0002-0001": "AMS A Slot 1 filament has run out. Please insert a new filament.",
-	"HMS_0700-2000-0002-0002": "AMS A Slot 1 is empty; please insert a new filament.",
-	"HMS_0700-2000-0002-0003": "AMS A Slot 1's filament may be broken in AMS.",
-	"HMS_0700-2000-0002-0004": "AMS A Slot 1 filament may be broken in the tool head.",
-	"HMS_0700-2000-0002-0005": "AMS A Slot 1 filament has run out, and purging the old filament went abnormally; please check whether the filament is stuck in the tool head.",
-	"HMS_0700-2000-0002-0006": "AMS A has detected a breakage of the PTFE tube during filament loading. Please check whether the PTFE tubes inside and outside the AMS have fallen off or been damaged.",
-	"HMS_0700-2000-0002-0007": "AMS A Slot 1 feed-out Hall sensor is disconnected. The connector may have poor contact.",
-	"HMS_0700-2000-0002-0008": "AMS A Slot 1 feed-in Hall sensor is disconnected, which may be due to poor connector contact.",
-	"HMS_0700-2000-0002-0009": "Failed to extrude AMS A Slot 1 filament; the extruder may be clogged or the filament may be too thin, causing the extruder to slip.",
-	"HMS_0700-2000-0002-000A": "Failed to adjust the buffer position. The AMS A Slot 1 filament or the buffer itself may be jammed.",
-	"HMS_0700-2000-0002-0010": "AMS A slot 1 feeds filament out of AMS timeout.",
-	"HMS_0700-2000-0002-0011": "AMS A slot 1 pulls filament back to AMS timeout.",
-	"HMS_0700-2000-0002-0012": "AMS A slot 1 feeder unit motor is stalled, cannot rotate the spool.",
-	"HMS_0700-2000-0002-0013": "AMS A slot 1 feeder unit motor has no signal, which may be due to poor contact in the motor connector or a motor fault.",
-	"HMS_0700-2000-0002-0014": "AMS A slot 1 filament odometer has no signal, which may be due to poor contact in the odometer connector or a odometer fault.",
-	"HMS_0700-2000-0002-0015": "AMS A slot 1 filament status is abnormal, which may be due to a filament breakage inside the AMS.",
-	"HMS_0700-2000-0002-0016": "AMS A slot 1 assist motor has slipped. Please pull out the filament, cut off the worn part, and then try again.",
-	"HMS_0700-2000-0002-0017": "AMS A slot 1 assist motor is stalled\uff0cdue to excessive resistance in the tube between AMS and the printer.",
-	"HMS_0700-2000-0002-0018": "AMS A slot 1 assist motor is stalled\uff0cdue to excessive resistance in the tube near AMS.",
-	"HMS_0700-2000-0002-0019": "AMS A slot 1 assist motor is stalled\uff0cdue to excessive resistance in the tube between AMS and the filament buffer.",
-	"HMS_0700-2000-0002-0021": "AMS A slot 1 assist motor is stalled\uff0cdue to excessive resistance in the tube between the filament buffer and the toolhead.",
-	"HMS_0700-2000-0002-0022": "AMS A slot 1 assist motor is stalled\uff0cdue to excessive resistance in the tube near the toolhead.",
-	"HMS_0700-2000-0002-0023": "AMS A slot 1 the tube inside the AMS is broken, or feed-out hall sensor is faulty and cannot detect the filament.",
-	"HMS_0700-2000-0002-0024": "AMS A slot 1 failed to rotate the filament spool when pulling filament back to AMS.",
-	"HMS_0700-2000-0002-0025": "AMS A slot 1 feed resistance is too high. Please reduce spool rotation resistance and avoid over-bent or over-long filament tubes.",
-	"HMS_0700-2000-0002-0026": "AMS A slot 1 assist motor overloaded. Excessive resistance in the filament tube between the AMS and the filament track switch.",
-	"HMS_0700-2000-0002-0027": "AMS A slot 1 assist motor overloaded. Excessive resistance in the filament tube between the filament track switch and the filament buffer.",
-	"HMS_0700-2000-0003-0001": "AMS A Slot 1 filament has run out. Please wait while old filament is purged.",
-	"HMS_0700-2000-0003-0002": "AMS A Slot 1 filament has run out and automatically switched to the slot with the same filament.",
-	"HMS_0700-2500-0002-0001": "AMS A uses printer power for drying during loading/printing. For better drying performance, please connect a power adapter.",
-	"HMS_0700-2600-0002-0001": "The induction hotend is heating up, and the drying power of AMS A will be reduced. Please connect the power adapter or wait until the hotend finishes heating.",
-	"HMS_0700-3000-0001-0001": "The AMS A RFID 1 board has an error.",
-	"HMS_0700-3500-0001-0002": "AMS A The humidity sensor is disconnected, which may be due to poor connector contact.",
-	"HMS_0700-4000-0002-0001": "AMS A Filament buffer position signal lost: the cable or position sensor may be malfunctioning.",
-	"HMS_0700-4000-0002-0002": "Filament buffer position signal error: the position sensor may be malfunctioning.",
-	"HMS_0700-4000-0002-0003": "The AMS Hub communication is abnormal; the cable may be not well connected.",
-	"HMS_0700-4000-0002-0004": "The filament buffer signal is abnormal; the spring may be stuck, or the filament may be tangled.",
-	"HMS_0700-4500-0002-0001": "The filament cutter sensor is malfunctioning; please check whether the connector is properly plugged in.",
-	"HMS_0700-4500-0002-0002": "The filament cutter's cutting distance is too large. The XY motor may lose steps.",
-	"HMS_0700-4500-0002-0003": "The filament cutter handle has not been released. The handle or blade may be jammed, or there could be an issue with the filament sensor connection.",
-	"HMS_0700-5000-0002-0001": "AMS A communication is abnormal; please check the connection cable.",
-	"HMS_0700-5100-0003-0001": "The AMS is disabled; please load filament from the spool holder.",
-	"HMS_0700-5500-0001-0002": "The PTFE tube connection between the toolhead and buffer is incorrect. Please ensure the upper port of the buffer is connected to the left extruder, and the lower port is connected to the right extruder.",
-	"HMS_0700-5500-0001-0003": "AMS A was detected offline during the AMS initialization process.",
-	"HMS_0700-5500-0001-0004": "The binding between AMS A and the extruder is incorrect. Please run the AMS Setup.",
-	"HMS_0700-5500-0001-0005": "Please connect the PTFE tube along the correct path, from the AMS through the buffer and then to the toolhead. Bypassing the buffer may cause the AMS to fail to feed filament properly.",
-	"HMS_0700-5500-0002-0001": "A new AMS detected. Please set it up to check which extruder the AMS is connected to.",
-	"HMS_0700-6000-0002-0001": "The AMS A Slot 1 is overloaded. The filament may be tangled or the filament buffer may be stuck.",
-	"HMS_0700-7000-0002-0001": "Failed to pull out the AMS A Slot 1 filament from the extruder. Possible cause: clogged extruder or broken filament inside the extruder.",
-	"HMS_0700-7000-0002-0002": "Failed to feed the filament into the toolhead. Possible cause: filament or spool stuck.",
-	"HMS_0700-7000-0002-0003": "Failed to extrude the filament. Possible cause: extruder or nozzle clog.",
-	"HMS_0700-7000-0002-0004": "Failed to pull back the filament from the toolhead to AMS. Possible cause: filament or spool stuck.",
-	"HMS_0700-7000-0002-0005": "Failed to feed the filament outside the AMS. Please clip the end of the filament flat and check to see if the spool is stuck.",
-	"HMS_0700-7000-0002-0006": "Timeout purging old filament. Possible cause: filament stuck or the extruder/nozzle clog.",
-	"HMS_0700-7000-0002-0007": "AMS filament ran out. Please put a new filament into the same slot in AMS and resume.",
-	"HMS_0700-7000-0002-0008": "Failed to get AMS mapping table; please select \"Resume\" to retry.",
-	"HMS_0700-7000-0002-000A": "Failed to get filament-hotend mapping table from the slicing file.",
-	"HMS_0700-7400-0001-0001": "The Filament Track Switch is offline. Please check for disconnected cables or loose connectors.",
-	"HMS_0700-7400-0002-0006": "The Filament Track Switch was connected during AMS initialization. Please unplug the Filament Track Switch and reconnect it after AMS initialization is complete.",
-	"HMS_0700-7400-0002-0007": "Filament Track Switch detected during loading/unloading. Please remove it and reconnect after the process is complete.",
-	"HMS_0700-7500-0001-0005": "The Filament Track Switch was connected while filament is still in the extruder or buffer. Please unplug the switch, manually unload the filament, then reconnect and try again.",
-	"HMS_0700-7500-0001-0008": "Filament was detected in the extruder or filament buffer when the Filament Track Switch was unplugged. Reconnect the switch first, manually unload the filament, and then unplug it.",
-	"HMS_0700-8000-0001-0001": "AMS A Heater 1, heater malfunction or abnormal current sensor detected.",
-	"HMS_0700-8000-0001-0002": "AMS A The heater 1 is disconnected, which may be due to poor connector contact.",
-	"HMS_0700-8000-0001-0003": "AMS A The heater 1 is short-circuited, which may be due to a wiring short or heater damage.",
-	"HMS_0700-8000-0001-0004": "AMS A The heater 1 is heating abnormally.",
-	"HMS_0700-8600-0001-0004": "AMS A is not correctly bound to the filament track switch. Please run AMS setup again.",
-	"HMS_0700-8600-0002-0002": "The AMS has been detected. Please set it up to confirm which side of the Filament Track Switch inlet the AMS is connected to.",
-	"HMS_0700-8700-0002-0001": "A filament jam or component damage may have occurred at the Filament Track Switch.",
-	"HMS_0700-8700-0002-0003": "Filament is blocked at the inlet of the filament track switch.",
-	"HMS_0700-8700-0002-0009": "AMS setup error: Filament track switch detected online, but filament did not pass through. Please check the PTFE tube connection between the AMS and the filament track switch; disconnect the switch if not in use.",
-	"HMS_0700-8800-0002-0003": "Abnormal movement detected on the IN-A side of the Filament Track Switch. This may be caused by debris obstruction, a loose cable, or a damaged coil. Please refer to the Wiki for troubleshooting.",
-	"HMS_0700-8900-0002-0004": "Abnormal switching detection sensor detected on the IN-A side of the Filament Track Switch. The cable on this side may be loose. Please reconnect or replace the sensor and try again.",
-	"HMS_0700-8A00-0002-0005": "Abnormal switching detection sensor detected on the IN-B side of the Filament Track Switch. The cable on this side may be loose. Please reconnect or replace the sensor and try again.",
-	"HMS_0700-8B00-0002-0006": "Abnormal feed detection sensor detected on the IN-A side of the Filament Track Switch. The cable on this side may be loose. Please reconnect or replace the sensor and try again.",
-	"HMS_0700-8C00-0002-0007": "Abnormal feed detection sensor detected on the IN-B side of the Filament Track Switch. The cable on this side may be loose. Please reconnect or replace the sensor and try again.",
-	"HMS_0700-8D00-0002-0008": "Abnormal coil resistance detected on the IN-A side of the Filament Track Switch. Please refer to the Wiki to replace the coil assembly.",
-	"HMS_0700-8E00-0002-0009": "Abnormal coil resistance detected on the IN-B side of the Filament Track Switch. Please refer to the Wiki to replace the coil assembly.",
-	"HMS_0700-8F00-0002-0002": "Abnormal movement detected on the IN-B side of the Filament Track Switch. This may be caused by debris obstruction, a loose cable, or a damaged coil. Please refer to the Wiki for troubleshooting.",
-	"HMS_0700-9000-0001-0002": "AMS A The coil resistance of exhaust valve 1 is abnormal, which may be due to abnormal wiring or damage.",
-	"HMS_0700-9000-0001-0003": "AMS A The exhaust valve 1 is not connected, which may be due to poor connector contact.",
-	"HMS_0700-9000-0001-0004": "The current sensor of AMS A exhaust valve 1 is abnormal; please get in touch with customer support to replace the AMS mainboard.",
-	"HMS_0700-9000-0002-0001": "AMS A The operation of the exhaust valve 1 is abnormal, which may be due to excessive resistance.",
-	"HMS_0700-9200-0001-0001": "AMS A The cooling fan of heater 1 is blocked, which may be due to the fan being stuck.",
-	"HMS_0700-9200-0002-0002": "AMS A The cooling fan speed of heater 1 is too low, which could be due to excessive fan resistance.",
-	"HMS_0700-9200-0002-0003": "The AMS A heater 1 cooling fan cannot start because the power adapter is not connected.",
-	"HMS_0700-9400-0001-0001": "AMS A The temperature sensor of heater 1 is offline, which may be due to poor connector contact.",
-	"HMS_0700-9600-0001-0001": "AMS A The drying process may experience thermal runaway. Please turn off the AMS power supply.",
-	"HMS_0700-9600-0001-0003": "AMS A Unable to start drying; please pull out the filament from filament hub and try again.",
-	"HMS_0700-9600-0002-0002": "AMS A Environmental temperature is too low, which will affect the drying capability.",
-	"HMS_0700-9700-0003-0001": "AMS A chamber temperature is too high; auxiliary feeding or RFID reading is currently not allowed.",
-	"HMS_0700-9800-0002-0001": "AMS A The power adapter voltage is too low, which may result in insufficient drying temperature. Please replace the power adapter.",
-	"HMS_0700-F000-0002-0001": "Filament and hotend matching failed. Please verify that the hotend in the hotend rack slot 1 is correctly installed.",
-	"HMS_07FE-2000-0002-0001": "External filament of left extruder has run out; please load a new filament.",
-	"HMS_07FE-2000-0002-0002": "No filament was detected in the left extruder from the external spool; please load the new filament.",
-	"HMS_07FE-2000-0002-0004": "Please pull the external filament from the left extruder.",
-	"HMS_07FE-4500-0002-0001": "The left filament cutter sensor is malfunctioning; please check whether the connector is properly plugged in.",
-	"HMS_07FE-4500-0002-0002": "The filament cutter's cutting distance is too large. Possible causes include the filament cutter stopper skipping teeth, motor losing steps, or the XY axis not being homed.",
-	"HMS_07FE-4500-0002-0003": "The filament cutter handle has not been released. The handle or blade may be jammed, or there could be an issue with the filament sensor connection.",
-	"HMS_07FE-6000-0002-0001": "External spool connected to left extruder may be tangled or jammed.",
-	"HMS_07FE-7000-0002-0003": "Please check if the material is coming out of the left nozzle. If not, gently push the material and try to extrude again.",
-	"HMS_07FE-8000-0001-0001": "Extruder switching abnormal. Please check whether the flow blocker is stuck or if filament is jammed inside the toolhead.",
-	"HMS_07FE-8000-0001-0002": "The Hall sensor for detecting the extruder switching angle is open-circuited; please check if the hall sensor is malfunctioning.",
-	"HMS_07FE-8000-0001-0003": "The hall signal for detecting the extruder switching angle is abnormal, possibly due to internal communication failure in the Toolhead module.",
-	"HMS_07FE-8000-0001-0004": "The hall sensor for detecting the extruder switching angle is short-circuited; please check if the hall sensor is malfunctioning.",
-	"HMS_07FE-8000-0001-0005": "The extruder's switching limit angle has drifted significantly. Please check if the flow blocker is jammed or if filament is stuck in the toolhead.",
-	"HMS_07FE-8000-0001-0006": "The TH board disconnected during the extruder switching process. Please check if the connecting cable is loose.",
-	"HMS_07FE-8000-0002-0001": "The lifting action is abnormal during the extruder switch. Please check whether the flow blocker is stuck or there is filament stuck in the toolhead.",
-	"HMS_07FE-8000-0002-0002": "Extruder switch lever failed to hit the rod, possibly due to the XY motor losing step. Please check for any obstructions blocking the toolhead movement, such as a PTFE tube.",
-	"HMS_07FE-8100-0001-0001": "The extruder switching motor is working abnormally. Please check whether the connecting cable is loose.",
-	"HMS_07FE-8100-0001-0002": "The position hall sensor of the Extruder Switching Motor has an open circuit. Please check whether the connecting cable is loose.",
-	"HMS_07FE-8100-0001-0003": "The hall signal of the Extruder Switching Motor is abnormal, possibly due to internal communication failure in the Toolhead module.",
-	"HMS_07FE-8100-0001-0004": "The position hall sensor of the Extruder Switching Motor has a short circuit; please check if the Hall sensor is malfunctioning.",
-	"HMS_07FE-8100-0002-0001": "The extruder switching action is abnormal. Please check whether there is something stuck in the toolhead.",
-	"HMS_07FE-A000-0002-0001": "The left nozzle cold pull process has timed out. Please click \"Retry\" and then manually pull out the filament.",
-	"HMS_07FF-2000-0002-0001": "External filament has run out; please load a new filament.",
-	"HMS_07FF-2000-0002-0002": "External filament is missing; please load a new filament.",
-	"HMS_07FF-2000-0002-0004": "Please pull the external filament from the extruder.",
-	"HMS_07FF-2000-0002-0008": "The Auxiliary Extruder failed to unload the filament, possibly due to slippage caused by worn filament. Please pull out the filament manually, cut off the worn section, and try again.",
-	"HMS_07FF-2000-0002-0009": "Auxiliary extruder feeding failed, possibly due to a clogged filament tube or worn filament, causing the extruder to slip. Please remove the filament, clear the tube, trim the worn section, and try again.",
-	"HMS_07FF-4500-0002-0001": "The filament cutter sensor is malfunctioning; please check whether the connector is properly plugged in.",
-	"HMS_07FF-6000-0002-0001": "External spool may be tangled or jammed.",
-	"HMS_07FF-7000-0002-0003": "Please check if the filament is coming out of the nozzle. If not, gently push the material and try to extrude again.",
-	"HMS_07FF-7000-0002-0009": "Failure to feed material from the right extruder to the toolhead may be due to the filament not being gripped by the extruder or a loose PTFE tube between the extruder and the toolhead.",
-	"HMS_07FF-A000-0002-0001": "The nozzle cold pull process has timed out. Please click \"Retry\" and then manually pull out the filament.",
-	"HMS_0C00-0100-0001-0001": "Micro Lidar is offline. Please check the hardware connection.",
-	"HMS_0C00-0100-0001-0003": "Synchronization between the Micro Lidar and MC is abnormal. Please restart your printer.",
-	"HMS_0C00-0100-0001-0004": "Toolhead Camera lens seems to be dirty. Please clean the lens.",
-	"HMS_0C00-0100-0001-0005": "Micro Lidar parameter is abnormal. Please contact customer support.",
-	"HMS_0C00-0100-0001-000A": "The Micro Lidar LED may be broken.",
-	"HMS_0C00-0100-0001-000B": "Failed to calibrate Micro Lidar. Please make sure the calibration chart is clean and not obscured. Then, run machine calibration again.",
-	"HMS_0C00-0100-0001-0011": "The Live View Camera calibration failed, please recalibrate. Ensure the build plate is empty, and the camera view is clear and properly oriented. Please contact customer support if repeated failures occur.",
-	"HMS_0C00-0100-0001-001C": "Micro Lidar calibration failed. The toolhead camera installation angle is too large. Refer to the Wiki to reinstall the toolhead camera, then run mount calibration.",
-	"HMS_0C00-0100-0002-0002": "Micro Lidar camera is malfunctioning. Please refer to the Wiki for troubleshooting.",
-	"HMS_0C00-0100-0002-0007": "Micro Lidar laser parameters have drifted. Please re-calibrate your printer.",
-	"HMS_0C00-0100-0002-0008": "Failed to get image from Live View Camera. Spaghetti and waste chute pileup detection is not available at this time.",
-	"HMS_0C00-0100-0002-0014": "Nozzle Camera is malfunctioning. If this issue occurs multiple times during printing, please contact customer support.",
-	"HMS_0C00-0100-0002-0017": "Nozzle camera lens is dirty, which may affect the AI monitoring functionality. Please clean the surface of the nozzle camera lens as soon as possible.",
-	"HMS_0C00-0200-0001-0001": "The horizontal laser is not lit. Please check if it's covered or hardware connection has a problem.",
-	"HMS_0C00-0200-0001-0005": "A new Micro Lidar was detected. Please calibrate it on the Calibration page before use.",
-	"HMS_0C00-0200-0002-0002": "The horizontal laser line is too wide. Please check if the heatbed is dirty.",
-	"HMS_0C00-0200-0002-0003": "The horizontal laser is not bright enough at homing position. Please clean or replace the heatbed if this message appears repeatedly.",
-	"HMS_0C00-0200-0002-0004": "Nozzle height seems to be too low. Please check if the nozzle is worn or tilted. Re-calibrate Lidar if the nozzle has been replaced.",
-	"HMS_0C00-0200-0002-0006": "Nozzle height seems to be too high. Please check if there is residual filament attached to the nozzle.",
-	"HMS_0C00-0300-0001-0009": "The first layer inspection module rebooted abnormally. The inspection result may be inaccurate.",
-	"HMS_0C00-0300-0002-0001": "Filament exposure metering failed because laser reflection is too weak on this material. First layer inspection may be inaccurate.",
-	"HMS_0C00-0300-0002-0002": "First layer inspection terminated due to abnormal Lidar data.",
-	"HMS_0C00-0300-0002-0004": "First layer inspection is not supported for the current print job.",
-	"HMS_0C00-0300-0002-0005": "First layer inspection timed out abnormally, and the current results may be inaccurate.",
-	"HMS_0C00-0300-0002-000C": "The build plate localization marker was not detected. Please check if the build plate is aligned correctly.",
-	"HMS_0C00-0300-0002-000E": "Your nozzle seems to be covered with jammed or clogged material.",
-	"HMS_0C00-0300-0002-0011": "The high-precision nozzle offset calibration failed; please recalibrate.",
-	"HMS_0C00-0300-0002-0012": "Foreign object detection is not working. The Live View Camera needs calibration. Please tap \"Settings &gt; Calibration\" on the printer screen. If a laser or cutting module is installed, please remove it before calibration.",
-	"HMS_0C00-0300-0002-0013": "Foreign object detection is not working. Please restart the devices or update the firmware.",
-	"HMS_0C00-0300-0002-0014": "Foreign object detection accuracy has decreased. If this occurs frequently, perform a Live View Camera calibration (\u201cSettings\u201d &gt; \u201cCalibration\u201d on the printer screen).",
-	"HMS_0C00-0300-0002-0015": "Foreign object detection is not working. Detected the Live View Camera has been replaced. Please tap \"Settings&gt;Calibration\" on printer screen and re-calibrate the Live View Camera.",
-	"HMS_0C00-0300-0002-0017": "Laser engraving Z-axis focus calibration failed. Please check if the Laser Test Material (350g paperboard) is properly placed and its surface is clean and intact.",
-	"HMS_0C00-0300-0002-0018": "Insufficient system memory was detected, and the foreign object detection function was not working. Please restart the devices or update the firmware after the task is completed",
-	"HMS_0C00-0300-0002-001C": "Your nozzle seems to be covered with jammed or clogged material.",
-	"HMS_0C00-0300-0003-0006": "Purged filament may have piled up in the waste chute. Please check and clean the chute.",
-	"HMS_0C00-0300-0003-0007": "Possible first layer defects have been detected. Please check the first layer quality and decide if the job should be stopped.",
-	"HMS_0C00-0300-0003-0008": "A possible spaghetti failure has been detected. Please check the print quality and decide whether to stop the job. Cleaning the build plate or drying the filament can effectively reduce the risk of spaghetti failure.",
-	"HMS_0C00-0300-0003-000B": "Inspecting the first layer: please wait a moment.",
-	"HMS_0C00-0300-0003-0010": "Your printer seems to be printing without extruding.",
-	"HMS_0C00-0400-0001-0005": "BirdsEye Camera malfunction: please contact customer support.",
-	"HMS_0C00-0400-0002-0007": "BirdsEye Camera is setting up. Please clear all objects and remove the mat. Make sure the marker is not obstructed. Meanwhile, clean both the BirdsEye Camera and Toolhead Camera, and remove any foreign objects blocking their view.",
-	"HMS_0C00-0400-0002-0017": "The visual marker was not detected during PrintThenCut; please re-paste the material to the correct position. Meanwhile, please clean the Toolhead Camera to prevent contamination and remove any objects that may obstruct its view.",
-	"HMS_0C00-0400-0002-0018": "Print-and-cut offset calibration failed. This cut may be inaccurate. Run cutting module offset calibration in the Toolbox before print-and-cut. If it still fails, check for blade tip wear.",
-	"HMS_0C00-0400-0002-0019": "The Birdseye Camera is installed offset. Please refer to the Wiki to reinstall it.",
-	"HMS_0C00-0400-0002-0023": "Thickness measurement failed, the Toolhead Camera was unable to detect the material surface.",
-	"HMS_0C00-0400-0002-0026": "Liveview Camera initialization failed, and some AI functions such as Spaghetti Detection will be disabled. Please restart the printer. If the problem persists, please contact customer support.",
-	"HMS_1200-1000-0001-0001": "The AMS lite Slot 1 motor has slipped. The extrusion wheel may be malfunctioning, or the filament may be too thin.",
-	"HMS_1200-1000-0002-0002": "The AMS lite Slot 1 motor is overloaded. The filament may be tangled or stuck.",
-	"HMS_1200-2000-0002-0003": "AMS lite Slot 1 filament may be broken in the PTFE tube.",
-	"HMS_1200-2000-0002-0004": "AMS lite Slot 1 filament may be broken in the tool head.",
-	"HMS_1200-2000-0002-0005": "AMS lite Slot 1 filament has run out, and purging the old filament went abnormally; please check to see if filament is stuck in the toolhead.",
-	"HMS_1200-2000-0002-0006": "Failed to extrude AMS lite Slot 1 filament; the extruder may be clogged or the filament may be too thin, causing the extruder to slip.",
-	"HMS_1200-2000-0002-0009": "Failed to extrude AMS lite slot 1 filament; the extruder may be clogged or the filament may be too thin, causing the extruder to slip.",
-	"HMS_1200-4500-0002-0001": "The filament cutter sensor is malfunctioning. Please check whether the connector is properly plugged in.",
-	"HMS_1200-4500-0002-0003": "The filament cutter handle has not been released. The handle or blade may be jammed, or there could be an issue with the filament sensor connection.",
-	"HMS_1200-5000-0002-0001": "AMS lite communication is abnormal; please check the connection cable.",
-	"HMS_1200-6000-0002-0001": "AMS lite slot 1 filament may be tangled or stuck.",
-	"HMS_1200-7000-0002-0002": "Failed to feed the AMS lite slot 1 filament into the toolhead. Possible cause: filament or spool stuck.",
-	"HMS_1200-7000-0002-0003": "Failed to extrude the filament. Possible cause: extruder or nozzle clog.",
-	"HMS_1200-7000-0002-0004": "Failed to pull back the AMS lite slot 1 filament from the toolhead. Possible cause: filament or spool stuck.",
-	"HMS_1200-8000-0002-0001": "AMS lite slot 1 filament may be tangled or stuck.",
-	"HMS_12FF-2000-0002-0004": "Please pull the filament on the spool holder out from the extruder.",
-	"HMS_12FF-2000-0002-0005": "Filament may be broken in the tool head.",
-	"HMS_12FF-2000-0002-0007": "Failed to check the filament location in the tool head; please click for more help.",
-	"HMS_12FF-6000-0002-0001": "The filament on the spool holder may be tangled or stuck.",
-	"HMS_1800-2000-0002-0026": "AMS-HT A assist motor overloaded. Excessive resistance in the filament tube between the AMS and the filament track switch.",
-	"HMS_1800-2000-0002-0027": "AMS-HT A assist motor overloaded. Excessive resistance in the filament tube between the filament track switch and the filament buffer.",
-	"HMS_1800-2400-0001-0007": "AMS-HT A door detection is abnormal, the Hall sensor connection may be loose or disconnected.",
-	"HMS_1800-2400-0002-0009": "AMS-HT A front cover is open. This may affect the drying performance or cause the filament to absorb moisture.",
-	"HMS_1800-7000-0002-0007": "AMS-HT filament ran out. Please put a new filament into the same slot in AMS and resume.",
-	"HMS_1800-8100-0001-0004": "AMS-HT A The heater 2 is heating abnormally.",
-	"HMS_1800-8600-0001-0004": "AMS-HT A is not correctly bound to the filament track switch. Please run AMS setup again.",
-	"HMS_1800-9000-0001-0004": "The current sensor of AMS-HT A exhaust valve 1 is abnormal; please get in touch with customer support to replace the AMS-HT mainboard.",
-	"HMS_1800-9100-0001-0003": "AMS-HT A The exhaust valve 2 is not connected, which may be due to poor connector contact.",
-	"HMS_1804-7000-0002-0002": "Failed to feed the AMS-HT E Slot 1 filament into the Toolhead. Possible cause: filament or spool stuck.",
-	"HMS_1A00-0100-0002-0003": "Error detected before induction hotend replacement: no hotend installed in slot 1 on the rack.",
-	"HMS_1A00-0100-0002-0004": "Error detected before induction hotend replacement: a hotend is already installed in slot 1 on the rack. Please remove it before continuing.",
-	"HMS_1A00-0100-0002-0020": "Abnormal condition detected while nesting the hotend in slot 1: a hotend is already installed. Please remove the existing one before clicking \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0021": "Abnormal condition detected while nesting the hotend in slot 1: no hotend detected on the toolhead. Please mount the hotend onto the toolhead before clicking \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0022": "Abnormal condition detected while nesting the hotend in slot 1. Please ensure no hotend is mounted on the toolhead and that a hotend is present in slot 1 on the rack before clicking \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0023": "Abnormal condition detected while nesting the hotend in slot 1: the rack failed to detect the hotend on the toolhead. Please check whether there is any debris on the hotend heatsink surface or if the front magnet has fallen off. If not, recalibrate the induction hotend rack.",
-	"HMS_1A00-0100-0002-0024": "Abnormal condition detected while nesting the hotend in slot 1: the rack failed to detach the hotend from the toolhead. Please make sure the locking lever is in the released position.",
-	"HMS_1A00-0100-0002-0025": "Abnormal condition detected while nesting the hotend in slot 1: the hotend has dropped. Please check for excessive filament remaining at the top of the hotend, trim the residue, reinstall the hotend into slot 1 on the rack, and then click \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0026": "Abnormal condition detected while nesting the hotend in slot 1: rack movement is blocked. Please check for any foreign objects, remove them, and then click \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0027": "Abnormal condition detected while nesting the hotend in slot 1: toolhead recentering retry failed. Please manually move the toolhead to the center of the build plate and then click \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0030": "Abnormal condition detected while fetching the hotend from slot 1: no hotend found in this position. Please install a hotend in the corresponding slot before clicking \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0031": "Abnormal condition detected while fetching the hotend from slot 1: a hotend is already mounted on the toolhead. Please remove the hotend from the toolhead before clicking \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0032": "Error detected while fetching the hotend from slot 1: the toolhead failed to detect the hotend on the rack. Please check for any debris in the induction hotend mounting slot on the toolhead. If none is found, recalibrate the induction hotend rack.",
-	"HMS_1A00-0100-0002-0033": "Error detected while fetching the hotend from slot 1: the rack failed to mount the hotend properly. Please check for excessive filament at the top of the hotend, debris in the contact area between the toolhead and the hotend, or if the locking lever is in the released position. After resolving the issue, click \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0034": "Error detected while fetching the hotend from slot 1: the hotend has dropped. Please check for excessive filament or other debris at the top of the hotend, trim or remove it, then reinstall the hotend into slot 1 on the rack and click \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0035": "Error detected while fetching the hotend from slot 1: rack movement is blocked. Please check for any foreign objects, remove them, and then click \u201cContinue.\u201d",
-	"HMS_1A00-0100-0002-0036": "Error detected while fetching the hotend from slot 1: toolhead recentering retry failed. Please manually move the toolhead to the center of the build plate and then click \u201cContinue.\u201d",
-	"HMS_1A00-1000-0002-0001": "Toolhead XY axis or hotend rack has not finished homing. Hotend replacement cannot proceed.",
-	"HMS_1A00-1100-0002-0002": "Hotend rack coarse homing failed. Please check for any obstructions.",
-	"HMS_1A00-1100-0002-0003": "Induction Hotend Rack coarse homing failed due to excessive travel. Please check the timing belt condition.",
-	"HMS_1A00-1200-0002-0001": "Induction hotend rack calibration failed: camera position calibration error. Please clean the toolhead camera, remove any debris from the thermal paper surface at the rear of the build plate, and remove residue from the nozzle.",
-	"HMS_1A00-1200-0002-0002": "Induction hotend rack calibration failed: the white marker on the rack\u2019s induction hotend docking module was not detected. Please refer to the Wiki to check if the marker is damaged or covered by dirt, and clean the toolhead camera.",
-	"HMS_1A00-1200-0002-0003": "Induction hotend rack calibration failed: abnormal calibration result. Please refer to the Wiki to check if the rack structure is deformed.",
-	"HMS_1A00-1200-0002-0004": "Hotend rack height calibration is abnormal. Please check for any foreign objects that may be causing the rack to jam.",
-	"HMS_1A00-1200-0002-0007": "The hotend rack motion timed out. Please check for any foreign objects or obstructions that may be causing the rack to jam.",
-	"HMS_1A00-1200-0002-0008": "The hotend rack tool lock rod position is abnormal. Please check for any foreign objects or obstructions that may be causing the lock rod to jam.",
-	"HMS_1A00-1200-0002-0009": "Induction hotend locking lever or rack lever release mechanism error. Please refer to the Wiki for inspection.",
-	"HMS_1A00-1200-0002-0010": "The Induction Hotend Rack has not been set up. Please follow the instructions to complete the setup before using its functions.",
-	"HMS_1A00-1200-0002-0011": "Induction hotend not detected before locking. Please check its installation on the toolhead.",
-	"HMS_1A00-1300-0002-0001": "The hotend rack is fully occupied. Please remove at least one hotend before proceeding.",
-	"HMS_1A00-2000-0002-0001": "Power outage occurred during hotend replacement. Please follow the Wiki guide to restore the setup, otherwise printing may fail.",
-	"HMS_1A00-2100-0001-0001": "Power loss occurred while fetching/nesting the hotend. Please nest the hotend back to slot 1 on the rack, then move the toolhead to the center of the build plate.",
-	"HMS_1A00-2100-0001-0002": "Power loss occurred while fetching/nesting the hotend. Please nest the hotend from slot 1 on the rack back onto the toolhead, then move the toolhead to the center of the build plate.",
-	"HMS_1A00-2100-0002-0001": "Power loss occurred while fetching/nesting hotend. Check if the hotend is in slot 1 on the rack. If not, nest it back to that slot and move the toolhead to the center of the build plate.",
-	"HMS_1A00-2100-0002-0002": "Power loss occurred while fetching/nesting the hotend. Check if it\u2019s mounted on the toolhead. If not, fetch it from slot 1 on the rack, nest it onto the toolhead, and move the toolhead to the center of the build plate.",
-	"HMS_1A00-3000-0001-0040": "The toolhead induction hotend presence detection sensor is open-circuit. Please reconnect the sensor cable or replace the sensor.",
-	"HMS_1A00-3000-0001-0041": "The toolhead induction hotend presence detection sensor is short-circuit. Please reconnect the sensor cable or replace the sensor.",
-	"HMS_1A00-E000-0001-0005": "The Induction Hotend Rack is blocked by an obstacle during homing. Please clear any obstructions from its movement path.",
-	"HMS_1A00-E000-0001-0006": "Induction Hotend Rack homing distance is too long. The timing belt may be loose. Please check and tighten.",
-}
\ No newline at end of file
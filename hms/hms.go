@@ -5,13 +5,18 @@ import (
 	"strings"
 )
 
+// wikiURL is the base of the per code troubleshooting pages, the path is shared by all models.
+const wikiURL = "https://wiki.bambulab.com/en/x1/troubleshooting/hmscode/"
+
 type Error struct {
 	Attribute uint32 `json:"attribute"`
 	Code      uint32 `json:"code"`
 }
 
+// NewError parses an HMS code such as "HMS_0300_0100_0001_0005", the "HMS_" prefix is optional and the groups may be separated by underscores or dashes.
 func NewError(code string) *Error {
 	code, _ = strings.CutPrefix(code, "HMS_")
+	code = strings.ReplaceAll(code, "-", "_")
 
 	var attrHigh, attrLow uint32
 	var codeHigh, codeLow uint32
@@ -32,23 +37,94 @@ func NewError(code string) *Error {
 
 func (e Error) GetCode() string {
 	if e.Attribute > 0 && e.Code > 0 {
-		attrHigh := (e.Attribute >> 16) & 0xffff
-		attrLow := e.Attribute & 0xffff
-		codeHigh := (e.Code >> 16) & 0xffff
-		codeLow := e.Code & 0xffff
-		return fmt.Sprintf("HMS_%04X_%04X_%04X_%04X", attrHigh, attrLow, codeHigh, codeLow)
+		return "HMS_" + e.groups("_")
 	}
 	return ""
 }
 
+// key returns the code in the format used by the [Database].
+func (e Error) key() string {
+	return "HMS_" + e.groups("-")
+}
+
+func (e Error) groups(sep string) string {
+	attrHigh := (e.Attribute >> 16) & 0xffff
+	attrLow := e.Attribute & 0xffff
+	codeHigh := (e.Code >> 16) & 0xffff
+	codeLow := e.Code & 0xffff
+	return fmt.Sprintf("%04X%s%04X%s%04X%s%04X", attrHigh, sep, attrLow, sep, codeHigh, sep, codeLow)
+}
+
 func (e Error) Error() string {
-	if msg, ok := HmsErrors[e.GetCode()]; ok {
+	if msg, ok := localize(hmsMessages, e.key(), DefaultLanguage); ok {
 		return msg
 	}
 
 	return e.GetCode()
 }
 
+// Module returns the printer module that raised the error, encoded in the top byte of the attribute.
+func (e Error) Module() Module {
+	return Module(e.Attribute >> 24)
+}
+
+// Index returns the instance of the module that raised the error, e.g. the AMS unit (0 for AMS A).
+func (e Error) Index() int {
+	return int((e.Attribute >> 16) & 0xff)
+}
+
+// Part returns the raw part byte of the attribute, identifying the component within the module.
+func (e Error) Part() int {
+	return int((e.Attribute >> 8) & 0xff)
+}
+
+// Slot returns the 0-based slot encoded in the part byte, only meaningful for modules with slots such as the AMS.
+func (e Error) Slot() int {
+	return e.Part() & 0x0f
+}
+
+// Severity returns the severity encoded in the upper half of the code.
+func (e Error) Severity() Severity {
+	s := e.Code >> 16
+	if s > uint32(SeverityNotification) {
+		return SeverityInvalid
+	}
+	return Severity(s)
+}
+
+// Info returns the decoded metadata of the error.
+func (e Error) Info() Info {
+	msg, _ := localize(hmsMessages, e.key(), DefaultLanguage)
+
+	return Info{
+		Code:     e.GetCode(),
+		Message:  msg,
+		Severity: e.Severity(),
+		Module:   e.Module(),
+		WikiURL:  wikiURL + e.groups("_"),
+	}
+}
+
+// Info describes an HMS code.
+type Info struct {
+	Code     string
+	Message  string // empty if the code is not in the HMS database
+	Severity Severity
+	Module   Module
+	WikiURL  string
+}
+
+// Lookup parses code (see [NewError] for accepted formats) and returns its metadata, the boolean reports whether the code has a known message.
+func Lookup(code string) (Info, bool) {
+	e := NewError(code)
+	if e == nil {
+		return Info{}, false
+	}
+
+	info := e.Info()
+	return info, info.Message != ""
+}
+
 type Module uint8
 
 const (
@@ -60,6 +136,25 @@ const (
 	ModuleMC        Module = 0x03
 )
 
+func (m Module) String() string {
+	switch m {
+	case ModuleDefault:
+		return "Default"
+	case ModuleMainboard:
+		return "Mainboard"
+	case ModuleXCam:
+		return "XCam"
+	case ModuleAMS:
+		return "AMS"
+	case ModuleToolhead:
+		return "Toolhead"
+	case ModuleMC:
+		return "Motion Controller"
+	default:
+		return fmt.Sprintf("Module 0x%02X", uint8(m))
+	}
+}
+
 type Severity uint8
 
 const (
@@ -68,3 +163,16 @@ const (
 	SeverityWarning                      // 0002
 	SeverityNotification                 // 0003
 )
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "Error"
+	case SeverityWarning:
+		return "Warning"
+	case SeverityNotification:
+		return "Notification"
+	default:
+		return "Invalid"
+	}
+}
@@ -0,0 +1,70 @@
+package hms
+
+import "testing"
+
+func TestErrorMessageLookup(t *testing.T) {
+	e := NewError("HMS_0300_0100_0001_0005")
+	if e == nil {
+		t.Fatal("NewError() = nil")
+	}
+
+	if got, want := e.GetCode(), "HMS_0300_0100_0001_0005"; got != want {
+		t.Errorf("GetCode() = %q, want %q", got, want)
+	}
+	if got, want := e.Error(), CurrentDatabase().Languages[DefaultLanguage].HMS["HMS_0300-0100-0001-0005"]; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestNewErrorFormats(t *testing.T) {
+	want := Error{Attribute: 0x03000100, Code: 0x00010005}
+
+	for _, code := range []string{"HMS_0300_0100_0001_0005", "HMS_0300-0100-0001-0005", "0300_0100_0001_0005"} {
+		if got := NewError(code); got == nil || *got != want {
+			t.Errorf("NewError(%q) = %+v, want %+v", code, got, want)
+		}
+	}
+
+	if got := NewError("not a code"); got != nil {
+		t.Errorf("NewError(invalid) = %+v, want nil", got)
+	}
+}
+
+func TestErrorMetadata(t *testing.T) {
+	e := Error{Attribute: 0x07012100, Code: 0x00020001} // AMS B, slot 2
+
+	if e.Module() != ModuleAMS {
+		t.Errorf("Module() = %v, want %v", e.Module(), ModuleAMS)
+	}
+	if e.Index() != 1 {
+		t.Errorf("Index() = %d, want 1", e.Index())
+	}
+	if e.Slot() != 1 {
+		t.Errorf("Slot() = %d, want 1", e.Slot())
+	}
+	if e.Severity() != SeverityWarning {
+		t.Errorf("Severity() = %v, want %v", e.Severity(), SeverityWarning)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	info, ok := Lookup("HMS_0300-0100-0001-0005")
+	if !ok {
+		t.Fatal("Lookup() ok = false, want true")
+	}
+
+	want := Info{
+		Code:     "HMS_0300_0100_0001_0005",
+		Message:  CurrentDatabase().Languages[DefaultLanguage].HMS["HMS_0300-0100-0001-0005"],
+		Severity: SeverityError,
+		Module:   ModuleMC,
+		WikiURL:  "https://wiki.bambulab.com/en/x1/troubleshooting/hmscode/0300_0100_0001_0005",
+	}
+	if info != want {
+		t.Errorf("Lookup() = %+v, want %+v", info, want)
+	}
+
+	if _, ok := Lookup("HMS_0300_0100_0001_FFFF"); ok {
+		t.Error("Lookup(unknown) ok = true, want false")
+	}
+}
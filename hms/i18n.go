@@ -0,0 +1,58 @@
+package hms
+
+import "strings"
+
+// DefaultLanguage is used when a message is not available in the requested language.
+const DefaultLanguage = "en"
+
+// Localized returns the error message in lang (a language tag such as "de" or "fr-CH"), falling back to English and then to the code.
+func (e Error) Localized(lang string) string {
+	if msg, ok := localize(hmsMessages, e.key(), lang); ok {
+		return msg
+	}
+	return e.GetCode()
+}
+
+// Localized returns the device error message in lang, falling back to English and then to the code.
+func (e PrintError) Localized(lang string) string {
+	if msg, ok := localize(deviceMessages, e.GetCode(), lang); ok {
+		return msg
+	}
+	return e.GetCode()
+}
+
+// LookupLang is [Lookup] with the message in lang, falling back to English.
+func LookupLang(code, lang string) (Info, bool) {
+	e := NewError(code)
+	if e == nil {
+		return Info{}, false
+	}
+
+	info := e.Info()
+	info.Message, _ = localize(hmsMessages, e.key(), lang)
+	return info, info.Message != ""
+}
+
+func hmsMessages(m Messages) map[string]string    { return m.HMS }
+func deviceMessages(m Messages) map[string]string { return m.Device }
+
+// localize looks key up in the most specific language of the current database matching lang, e.g. "fr-CH" tries "fr-ch", then "fr", then English.
+func localize(table func(Messages) map[string]string, key, lang string) (string, bool) {
+	db := CurrentDatabase()
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+
+	for lang != "" && lang != DefaultLanguage {
+		if msg, ok := table(db.Languages[lang])[key]; ok {
+			return msg, true
+		}
+
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+
+	msg, ok := table(db.Languages[DefaultLanguage])[key]
+	return msg, ok
+}
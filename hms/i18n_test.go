@@ -0,0 +1,58 @@
+package hms
+
+import "testing"
+
+func TestLocalizedFallback(t *testing.T) {
+	e := Error{Attribute: 0x03000100, Code: 0x00010005}
+	english := e.Error()
+
+	db := *CurrentDatabase()
+	db.Languages = map[string]Messages{
+		DefaultLanguage: db.Languages[DefaultLanguage],
+		"de":            {HMS: map[string]string{e.key(): "Deutsch"}},
+	}
+	withDatabase(t, &db)
+
+	tests := []struct {
+		lang string
+		want string
+	}{
+		{"de", "Deutsch"},
+		{"de-CH", "Deutsch"},
+		{"DE_at", "Deutsch"},
+		{"fr", english},
+		{"", english},
+		{"en", english},
+	}
+	for _, tt := range tests {
+		if got := e.Localized(tt.lang); got != tt.want {
+			t.Errorf("Localized(%q) = %q, want %q", tt.lang, got, tt.want)
+		}
+	}
+
+	info, ok := LookupLang("HMS_0300_0100_0001_0005", "de-DE")
+	if !ok || info.Message != "Deutsch" {
+		t.Errorf("LookupLang() = %+v, %v, want German message", info, ok)
+	}
+
+	unknown := Error{Attribute: 0x0F000000, Code: 0x0001FFFF}
+	if got := unknown.Localized("de"); got != unknown.GetCode() {
+		t.Errorf("Localized(unknown) = %q, want code", got)
+	}
+}
+
+func TestEmbeddedTranslation(t *testing.T) {
+	msgs, ok := CurrentDatabase().Languages["de"]
+	if !ok {
+		t.Skip("embedded database has no German messages, run go generate ./hms")
+	}
+
+	e := NewError("HMS_0300_0100_0001_0005")
+	msg, ok := msgs.HMS[e.key()]
+	if !ok {
+		t.Fatalf("no German message for %s", e.key())
+	}
+	if got := e.Localized("de-DE"); got != msg || got == e.Error() {
+		t.Errorf("Localized(de-DE) = %q, want the German message %q", got, msg)
+	}
+}
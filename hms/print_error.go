@@ -0,0 +1,58 @@
+package hms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PrintError is a device error as reported in the print_error and mc_print_error_code fields, displayed by the printer and Bambu Studio as e.g. "0500-4003".
+type PrintError struct {
+	Code uint32 `json:"code"`
+}
+
+// NewPrintError wraps a print_error value, returning nil for 0 (no error).
+func NewPrintError(code uint32) *PrintError {
+	if code == 0 {
+		return nil
+	}
+	return &PrintError{Code: code}
+}
+
+// ParsePrintError parses a device error code in its displayed form ("0500-4003" or "0500_4003") or as the decimal string reported in mc_print_error_code.
+// It returns nil for empty or zero codes and codes it cannot parse.
+func ParsePrintError(code string) *PrintError {
+	code = strings.TrimSpace(code)
+
+	if high, low, ok := strings.Cut(strings.ReplaceAll(code, "_", "-"), "-"); ok {
+		h, errHigh := strconv.ParseUint(high, 16, 16)
+		l, errLow := strconv.ParseUint(low, 16, 16)
+		if errHigh != nil || errLow != nil {
+			return nil
+		}
+		return NewPrintError(uint32(h<<16 | l))
+	}
+
+	v, err := strconv.ParseUint(code, 10, 32)
+	if err != nil {
+		return nil
+	}
+	return NewPrintError(uint32(v))
+}
+
+func (e PrintError) GetCode() string {
+	return fmt.Sprintf("%04X-%04X", e.Code>>16, e.Code&0xffff)
+}
+
+func (e PrintError) Error() string {
+	if msg, ok := localize(deviceMessages, e.GetCode(), DefaultLanguage); ok {
+		return msg
+	}
+
+	return e.GetCode()
+}
+
+// Module returns the printer module that raised the error, encoded in the top byte of the code like HMS attributes.
+func (e PrintError) Module() Module {
+	return Module(e.Code >> 24)
+}
@@ -0,0 +1,33 @@
+package hms
+
+import "testing"
+
+func TestParsePrintError(t *testing.T) {
+	want := PrintError{Code: 0x05004003}
+
+	for _, code := range []string{"0500-4003", "0500_4003", "83902467"} {
+		if got := ParsePrintError(code); got == nil || *got != want {
+			t.Errorf("ParsePrintError(%q) = %+v, want %+v", code, got, want)
+		}
+	}
+
+	for _, code := range []string{"", "0", "0000-0000", "nope"} {
+		if got := ParsePrintError(code); got != nil {
+			t.Errorf("ParsePrintError(%q) = %+v, want nil", code, got)
+		}
+	}
+}
+
+func TestPrintErrorMessage(t *testing.T) {
+	e := NewPrintError(0x05004003)
+
+	if got := e.GetCode(); got != "0500-4003" {
+		t.Errorf("GetCode() = %q, want %q", got, "0500-4003")
+	}
+	if got := e.Error(); got != CurrentDatabase().Languages[DefaultLanguage].Device["0500-4003"] {
+		t.Errorf("Error() = %q, want the device error message", got)
+	}
+	if got := NewPrintError(0x0500FFFF).Error(); got != "0500-FFFF" {
+		t.Errorf("Error() for unknown code = %q, want the code", got)
+	}
+}
@@ -16,6 +16,9 @@ type FtpClientConfig struct {
 	Port       int
 	Username   string
 	AccessCode string
+
+	// TLS secures the control and data connections, nil accepts any certificate.
+	TLS *tls.Config
 }
 
 type FtpClient struct {
@@ -37,13 +40,16 @@ func NewFtpClient(cfg *FtpClientConfig) *FtpClient {
 func (c *FtpClient) Connect(ctx context.Context) error {
 	addr := fmt.Sprintf("%s:%d", c.config.Host, c.config.Port)
 
+	tlsConfig := &tls.Config{InsecureSkipVerify: true}
+	if c.config.TLS != nil {
+		tlsConfig = c.config.TLS.Clone()
+	}
+	tlsConfig.ServerName = c.config.Host // required for resolution, do not remove
+
 	conn, err := goftp.Dial(
 		addr,
 		goftp.DialWithContext(ctx),
-		goftp.DialWithTLS(&tls.Config{
-			InsecureSkipVerify: true,          // required for local communication, ignore warning
-			ServerName:         c.config.Host, // also required for resolution, do not remove
-		}),
+		goftp.DialWithTLS(tlsConfig),
 	)
 	if err != nil {
 		return err
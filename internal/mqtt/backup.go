@@ -0,0 +1,37 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// FilamentBackup holds the filament backup (auto refill) groups reported in filam_bak, each group is a bitmask of printer wide tray IDs.
+// Firmware versions disagree on the element type, numbers and hex strings are decoded and anything else is skipped so the report still parses.
+type FilamentBackup []uint32
+
+func (f *FilamentBackup) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		*f = nil
+		return nil
+	}
+
+	groups := make(FilamentBackup, 0, len(raw))
+	for _, r := range raw {
+		var n uint32
+		if err := json.Unmarshal(r, &n); err == nil {
+			groups = append(groups, n)
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(r, &s); err == nil {
+			if v, err := strconv.ParseUint(s, 16, 32); err == nil {
+				groups = append(groups, uint32(v))
+			}
+		}
+	}
+
+	*f = groups
+	return nil
+}
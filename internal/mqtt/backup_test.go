@@ -0,0 +1,28 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFilamentBackupUnmarshal(t *testing.T) {
+	tests := map[string]FilamentBackup{
+		`[]`:                     {},
+		`[3, 12]`:                {3, 12},
+		`["3", "c0"]`:            {3, 0xc0},
+		`[3, {"unknown": true}]`: {3},
+		`{"not": "an array"}`:    nil,
+	}
+
+	for in, want := range tests {
+		var got FilamentBackup
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Errorf("Unmarshal(%s) error = %v", in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Unmarshal(%s) = %#v, want %#v", in, got, want)
+		}
+	}
+}
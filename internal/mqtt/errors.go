@@ -2,4 +2,7 @@ package mqtt
 
 import "errors"
 
-var ErrClosed = errors.New("mqtt client closed")
+var (
+	ErrClosed           = errors.New("mqtt client closed")
+	ErrSessionTakenOver = errors.New("mqtt session taken over by another client")
+)
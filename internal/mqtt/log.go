@@ -0,0 +1,48 @@
+package mqtt
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+// LevelCritical is the level of paho's CRITICAL logger, above [slog.LevelError].
+const LevelCritical = slog.LevelError + 4
+
+// SetPahoLogger routes paho's internal loggers to l with their levels mapped to slog levels, nil discards them again.
+// paho's loggers are process wide so this affects every client.
+func SetPahoLogger(l *slog.Logger) {
+	if l == nil {
+		paho.ERROR, paho.CRITICAL, paho.WARN, paho.DEBUG = paho.NOOPLogger{}, paho.NOOPLogger{}, paho.NOOPLogger{}, paho.NOOPLogger{}
+		return
+	}
+
+	l = l.With("component", "paho")
+	paho.ERROR = pahoLogger{l, slog.LevelError}
+	paho.CRITICAL = pahoLogger{l, LevelCritical}
+	paho.WARN = pahoLogger{l, slog.LevelWarn}
+	paho.DEBUG = pahoLogger{l, slog.LevelDebug}
+}
+
+// pahoLogger adapts a slog logger to paho's Logger interface at a fixed level.
+type pahoLogger struct {
+	log   *slog.Logger
+	level slog.Level
+}
+
+func (p pahoLogger) Println(v ...any) {
+	if !p.log.Enabled(context.Background(), p.level) {
+		return
+	}
+	p.log.Log(context.Background(), p.level, strings.TrimSpace(fmt.Sprintln(v...)))
+}
+
+func (p pahoLogger) Printf(format string, v ...any) {
+	if !p.log.Enabled(context.Background(), p.level) {
+		return
+	}
+	p.log.Log(context.Background(), p.level, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
@@ -0,0 +1,32 @@
+package mqtt
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+func TestSetPahoLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetPahoLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	defer SetPahoLogger(nil)
+
+	paho.DEBUG.Println("[client]", "filtered")
+	paho.WARN.Printf("%s %s", "[net]", "connection reset")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log output %q: %v", buf.String(), err)
+	}
+	if record["level"] != "WARN" || record["msg"] != "[net] connection reset" || record["component"] != "paho" {
+		t.Errorf("log record = %v, want the paho warning", record)
+	}
+
+	SetPahoLogger(nil)
+	if _, ok := paho.ERROR.(paho.NOOPLogger); !ok {
+		t.Error("paho logger not reset")
+	}
+}
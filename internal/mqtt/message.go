@@ -7,99 +7,114 @@ type Message struct {
 }
 
 type Print struct {
-	Ams                     AMS           `json:"ams"`
-	AmsRfidStatus           int           `json:"ams_rfid_status"`
-	AmsStatus               int           `json:"ams_status"`
-	AuxPartFan              bool          `json:"aux_part_fan"`
-	BedTargetTemper         float64       `json:"bed_target_temper"`
-	BedTemper               float64       `json:"bed_temper"`
-	BigFan1Speed            string        `json:"big_fan1_speed"`
-	BigFan2Speed            string        `json:"big_fan2_speed"`
-	ChamberTemper           float64       `json:"chamber_temper"`
-	Command                 string        `json:"command"`
-	CoolingFanSpeed         string        `json:"cooling_fan_speed"`
-	FailReason              string        `json:"fail_reason"`
-	FanGear                 int           `json:"fan_gear"`
-	FilamBak                []any         `json:"filam_bak"`
-	ForceUpgrade            bool          `json:"force_upgrade"`
-	GcodeFile               string        `json:"gcode_file"`
-	GcodeFilePreparePercent string        `json:"gcode_file_prepare_percent"`
-	GcodeStartTime          string        `json:"gcode_start_time"`
-	GcodeState              string        `json:"gcode_state"`
-	HeatbreakFanSpeed       string        `json:"heatbreak_fan_speed"`
-	HomeFlag                int           `json:"home_flag"`
-	HwSwitchState           int           `json:"hw_switch_state"`
-	Ipcam                   IPCam         `json:"ipcam"`
-	LayerNum                int           `json:"layer_num"`
-	Lifecycle               string        `json:"lifecycle"`
-	LightsReport            []LightReport `json:"lights_report"`
-	Maintain                int           `json:"maintain"`
-	McPercent               int           `json:"mc_percent"`
-	McPrintErrorCode        string        `json:"mc_print_error_code"`
-	McPrintStage            string        `json:"mc_print_stage"`
-	McPrintSubStage         int           `json:"mc_print_sub_stage"`
-	McRemainingTime         int           `json:"mc_remaining_time"`
-	MessProductionState     string        `json:"mess_production_state"`
-	NozzleDiameter          string        `json:"nozzle_diameter"`
-	NozzleTargetTemper      float64       `json:"nozzle_target_temper"`
-	NozzleTemper            float64       `json:"nozzle_temper"`
-	Online                  Online        `json:"online"`
-	PrintError              int           `json:"print_error"`
-	PrintGcodeAction        int           `json:"print_gcode_action"`
-	PrintRealAction         int           `json:"print_real_action"`
-	PrintType               string        `json:"print_type"`
-	ProfileID               string        `json:"profile_id"`
-	ProjectID               string        `json:"project_id"`
-	QueueNumber             int           `json:"queue_number"`
-	Sdcard                  bool          `json:"sdcard"`
-	SequenceID              string        `json:"sequence_id"`
-	SpdLvl                  int           `json:"spd_lvl"`
-	SpdMag                  int           `json:"spd_mag"`
-	Stg                     []any         `json:"stg"`
-	StgCur                  int           `json:"stg_cur"`
-	SubtaskID               string        `json:"subtask_id"`
-	SubtaskName             string        `json:"subtask_name"`
-	TaskID                  string        `json:"task_id"`
-	TotalLayerNum           int           `json:"total_layer_num"`
-	UpgradeState            UpgradeState  `json:"upgrade_state"`
-	Upload                  Upload        `json:"upload"`
-	VtTray                  Tray          `json:"vt_tray"`
-	WifiSignal              string        `json:"wifi_signal"`
-	Xcam                    XCam          `json:"xcam"`
-	XcamStatus              string        `json:"xcam_status"`
+	Ams                     AMS            `json:"ams"`
+	AmsRfidStatus           int            `json:"ams_rfid_status"`
+	AmsStatus               int            `json:"ams_status"`
+	AuxPartFan              bool           `json:"aux_part_fan"`
+	BedTargetTemper         float64        `json:"bed_target_temper"`
+	BedTemper               float64        `json:"bed_temper"`
+	BigFan1Speed            string         `json:"big_fan1_speed"`
+	BigFan2Speed            string         `json:"big_fan2_speed"`
+	ChamberTemper           float64        `json:"chamber_temper"`
+	Command                 string         `json:"command"`
+	CoolingFanSpeed         string         `json:"cooling_fan_speed"`
+	FailReason              string         `json:"fail_reason"`
+	FanGear                 int            `json:"fan_gear"`
+	FilamBak                FilamentBackup `json:"filam_bak"`
+	ForceUpgrade            bool           `json:"force_upgrade"`
+	GcodeFile               string         `json:"gcode_file"`
+	GcodeFilePreparePercent string         `json:"gcode_file_prepare_percent"`
+	GcodeStartTime          string         `json:"gcode_start_time"`
+	GcodeState              string         `json:"gcode_state"`
+	HeatbreakFanSpeed       string         `json:"heatbreak_fan_speed"`
+	HomeFlag                int            `json:"home_flag"`
+	HwSwitchState           int            `json:"hw_switch_state"`
+	Ipcam                   IPCam          `json:"ipcam"`
+	JobID                   string         `json:"job_id"`
+	LayerNum                int            `json:"layer_num"`
+	Lifecycle               string         `json:"lifecycle"`
+	LightsReport            []LightReport  `json:"lights_report"`
+	Maintain                int            `json:"maintain"`
+	McPercent               int            `json:"mc_percent"`
+	McPrintErrorCode        string         `json:"mc_print_error_code"`
+	McPrintStage            string         `json:"mc_print_stage"`
+	McPrintSubStage         int            `json:"mc_print_sub_stage"`
+	McRemainingTime         int            `json:"mc_remaining_time"`
+	MessProductionState     string         `json:"mess_production_state"`
+	Msg                     int            `json:"msg"`
+	NozzleDiameter          string         `json:"nozzle_diameter"`
+	NozzleTargetTemper      float64        `json:"nozzle_target_temper"`
+	NozzleTemper            float64        `json:"nozzle_temper"`
+	Online                  Online         `json:"online"`
+	PrintError              int            `json:"print_error"`
+	PrintGcodeAction        int            `json:"print_gcode_action"`
+	PrintRealAction         int            `json:"print_real_action"`
+	PrintType               string         `json:"print_type"`
+	ProfileID               string         `json:"profile_id"`
+	ProjectID               string         `json:"project_id"`
+	QueueNumber             int            `json:"queue_number"`
+	Sdcard                  bool           `json:"sdcard"`
+	SequenceID              string         `json:"sequence_id"`
+	SpdLvl                  int            `json:"spd_lvl"`
+	SpdMag                  int            `json:"spd_mag"`
+	Stg                     []any          `json:"stg"`
+	StgCur                  int            `json:"stg_cur"`
+	SubtaskID               string         `json:"subtask_id"`
+	SubtaskName             string         `json:"subtask_name"`
+	TaskID                  string         `json:"task_id"`
+	TotalLayerNum           int            `json:"total_layer_num"`
+	UpgradeState            UpgradeState   `json:"upgrade_state"`
+	Upload                  Upload         `json:"upload"`
+	VtTray                  Tray           `json:"vt_tray"`
+	WifiSignal              string         `json:"wifi_signal"`
+	Xcam                    XCam           `json:"xcam"`
+	XcamStatus              string         `json:"xcam_status"`
 
 	HmsErrors []hms.Error `json:"hms"`
 }
 
 type AMS struct {
-	Ams              []AMSUnit `json:"ams"`
-	AmsExistBits     string    `json:"ams_exist_bits"`
-	InsertFlag       bool      `json:"insert_flag"`
-	PowerOnFlag      bool      `json:"power_on_flag"`
-	TrayExistBits    string    `json:"tray_exist_bits"`
-	TrayIsBblBits    string    `json:"tray_is_bbl_bits"`
-	TrayNow          string    `json:"tray_now"`
-	TrayReadDoneBits string    `json:"tray_read_done_bits"`
-	TrayReadingBits  string    `json:"tray_reading_bits"`
-	TrayTar          string    `json:"tray_tar"`
-	TrayPre          string    `json:"tray_pre"`
-	Version          int       `json:"version"`
+	Ams                 []AMSUnit `json:"ams"`
+	AmsExistBits        string    `json:"ams_exist_bits"`
+	CalibrateRemainFlag bool      `json:"calibrate_remain_flag"`
+	InsertFlag          bool      `json:"insert_flag"`
+	PowerOnFlag         bool      `json:"power_on_flag"`
+	TrayExistBits       string    `json:"tray_exist_bits"`
+	TrayIsBblBits       string    `json:"tray_is_bbl_bits"`
+	TrayNow             string    `json:"tray_now"`
+	TrayReadDoneBits    string    `json:"tray_read_done_bits"`
+	TrayReadingBits     string    `json:"tray_reading_bits"`
+	TrayTar             string    `json:"tray_tar"`
+	TrayPre             string    `json:"tray_pre"`
+	Version             int       `json:"version"`
 }
 
 type AMSUnit struct {
-	Humidity string `json:"humidity"`
-	ID       string `json:"id"`
-	Temp     string `json:"temp"`
-	Tray     []Tray `json:"tray"`
+	DrySetting  *DrySetting `json:"dry_setting,omitempty"`
+	DryTime     int         `json:"dry_time,omitempty"`
+	Humidity    string      `json:"humidity"`
+	HumidityRaw string      `json:"humidity_raw,omitempty"`
+	ID          string      `json:"id"`
+	Info        string      `json:"info,omitempty"`
+	Temp        string      `json:"temp"`
+	Tray        []Tray      `json:"tray"`
+}
+
+type DrySetting struct {
+	DryDuration    int    `json:"dry_duration"`
+	DryFilament    string `json:"dry_filament"`
+	DryTemperature int    `json:"dry_temperature"`
 }
 
 type Tray struct {
 	ID            string   `json:"id"`
 	BedTemp       string   `json:"bed_temp,omitempty"`
 	BedTempType   string   `json:"bed_temp_type,omitempty"`
+	CaliIdx       int      `json:"cali_idx,omitempty"`
 	Cols          []string `json:"cols,omitempty"`
 	DryingTemp    string   `json:"drying_temp,omitempty"`
 	DryingTime    string   `json:"drying_time,omitempty"`
+	K             float64  `json:"k,omitempty"`
 	NozzleTempMax string   `json:"nozzle_temp_max,omitempty"`
 	NozzleTempMin string   `json:"nozzle_temp_min,omitempty"`
 	Remain        int      `json:"remain,omitempty"`
@@ -2,11 +2,15 @@ package mqtt
 
 import (
 	"context"
+	"crypto/rand"
 	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"net"
+	"strconv"
 	"sync"
 	"time"
 
@@ -15,24 +19,83 @@ import (
 )
 
 const (
-	clientID = "torbenconto/bambulabs_api"
-	qos      = 0
+	// DefaultClientIDPrefix starts generated client IDs when no prefix is configured.
+	DefaultClientIDPrefix = "bambulabs_api"
+	qos                   = 0
+
+	// Brokers of MQTT 3.1.1 close the session of a client when another one connects with the same ID, without a reason.
+	// A connection closed by the broker within takeoverUptime of its CONNACK, takeoverDrops times within takeoverWindow,
+	// is reported as taken over. Reconnects are then delayed by takeoverBackoff, doubling up to takeoverMaxBackoff, so
+	// the clients stop kicking each other off.
+	takeoverUptime     = 5 * time.Second
+	takeoverWindow     = time.Minute
+	takeoverDrops      = 3
+	takeoverBackoff    = 30 * time.Second
+	takeoverMaxBackoff = 5 * time.Minute
 )
 
 type MqttConfig struct {
-	Host         net.IP
-	Port         int
+	Host net.IP
+	Port int
+	// Broker is the host:port of a broker other than the printer, such as the cloud, it overrides Host and Port.
+	Broker       string
 	Username     string
 	SerialNumber string
 	AccessCode   string
+
+	// ClientIDPrefix starts the generated client ID, which ends in a random suffix so that connections never share an ID.
+	// Empty uses DefaultClientIDPrefix.
+	ClientIDPrefix string
+	// ClientID, if set, is used as is instead of a generated ID.
+	ClientID string
+
+	// TLS secures the connection, nil accepts any certificate.
+	TLS *tls.Config
+
+	// Tap, if set, is called with every received payload and published command.
+	Tap Tap
+
+	// Logger receives the clients logs, nil uses [slog.Default].
+	Logger *slog.Logger
+}
+
+// Tap observes traffic, incoming is true for messages received from the printer.
+type Tap func(incoming bool, topic string, payload []byte)
+
+// ConnState is the state of the connection to the broker.
+type ConnState uint8
+
+const (
+	StateConnected ConnState = iota
+	StateReconnecting
+	StateDisconnected
+)
+
+// ConnEvent is a change of the connection, Err is the cause of a lost connection or failed reconnect attempt.
+type ConnEvent struct {
+	State ConnState
+	Err   error
 }
 
 type MqttClient struct {
 	config      *MqttConfig
+	log         *slog.Logger
 	client      paho.Client
 	messageChan chan []byte
+	connEvents  chan ConnEvent
 	connected   chan struct{}
 
+	clientID string
+
+	// eventMu serializes connection events, paho runs the connect and lost handlers in separate goroutines
+	eventMu     sync.Mutex
+	stateMu     sync.Mutex
+	state       ConnState // last state sent on connEvents
+	up          bool      // onConnect ran for the current connection
+	connectedAt time.Time
+	drops       []time.Time // connections closed by the broker right after connecting, within takeoverWindow
+	takeovers   int         // consecutive takeovers reported, sets the reconnect delay
+
 	closeOnce sync.Once
 	stop      chan struct{}
 }
@@ -41,28 +104,74 @@ func (c *MqttClient) MessageChan() <-chan []byte {
 	return c.messageChan
 }
 
+// ConnEvents returns the changes of the connection in order, it must be drained for the client to make progress.
+func (c *MqttClient) ConnEvents() <-chan ConnEvent {
+	return c.connEvents
+}
+
 func NewMqttClient(cfg *MqttConfig) (*MqttClient, error) {
+	id := cfg.ClientID
+	if id == "" {
+		var err error
+		if id, err = newClientID(cfg.ClientIDPrefix); err != nil {
+			return nil, err
+		}
+	}
+
+	broker := cfg.Broker
+	if broker == "" {
+		broker = net.JoinHostPort(cfg.Host.String(), strconv.Itoa(cfg.Port))
+	}
+
+	tlsConfig := cfg.TLS
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
 	opts := paho.NewClientOptions().
-		AddBroker(fmt.Sprintf("mqtts://%s:%d", cfg.Host.String(), cfg.Port)). // use mqtts
-		SetClientID(clientID).
+		AddBroker("mqtts://" + broker).
+		SetClientID(id).
 		SetUsername(cfg.Username).
 		SetPassword(cfg.AccessCode).
-		SetTLSConfig(&tls.Config{InsecureSkipVerify: true}). // required for local printer commmunication
+		SetTLSConfig(tlsConfig).
 		SetAutoReconnect(true).
 		SetKeepAlive(30 * time.Second)
 
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	client := &MqttClient{
 		config:      cfg,
+		clientID:    id,
+		log:         logger.With("client_id", id),
 		messageChan: make(chan []byte, 200),
+		connEvents:  make(chan ConnEvent, 16),
 		stop:        make(chan struct{}),
 		connected:   make(chan struct{}),
 	}
 
 	opts.SetOnConnectHandler(client.onConnect)
+	opts.SetReconnectingHandler(client.onReconnecting)
 	opts.SetDefaultPublishHandler(client.handleMessage)
 
-	opts.SetConnectionLostHandler(func(c paho.Client, err error) {
-		log.Printf("MQTT connection lost: %v", err)
+	// paho runs the reconnect loop and this handler concurrently, so reconnecting is reported from here to keep the order
+	opts.SetConnectionLostHandler(func(_ paho.Client, err error) {
+		client.eventMu.Lock()
+		defer client.eventMu.Unlock()
+
+		client.sendConnEvent(ConnEvent{State: StateDisconnected, Err: client.checkTakeover(err)})
+		client.sendConnEvent(ConnEvent{State: StateReconnecting})
+	})
+	opts.SetConnectionNotificationHandler(func(_ paho.Client, n paho.ConnectionNotification) {
+		client.eventMu.Lock()
+		defer client.eventMu.Unlock()
+
+		// failed reconnect attempts keep the reconnecting state but update the cause
+		if f, ok := n.(paho.ConnectionNotificationFailed); ok && client.isReconnecting() {
+			client.sendConnEvent(ConnEvent{State: StateReconnecting, Err: f.Reason})
+		}
 	})
 
 	client.client = paho.NewClient(opts)
@@ -75,10 +184,25 @@ func (c *MqttClient) onConnect(client paho.Client) {
 
 	token := client.Subscribe(topic, 0, c.handleMessage)
 	if err := waitToken(context.Background(), c.stop, token); err != nil {
-		log.Printf("MQTT subscribe failed: %v", err)
+		c.log.Error("mqtt subscribe failed", "topic", topic, "error", err)
+		return
+	}
+
+	c.eventMu.Lock()
+	defer c.eventMu.Unlock()
+
+	// the connection was lost while subscribing, the lost handler reports it and Connected would arrive after it
+	if !client.IsConnectionOpen() {
 		return
 	}
 
+	c.stateMu.Lock()
+	c.up = true
+	c.connectedAt = time.Now()
+	c.stateMu.Unlock()
+
+	c.sendConnEvent(ConnEvent{State: StateConnected})
+
 	select {
 	case <-c.connected:
 	default:
@@ -86,13 +210,110 @@ func (c *MqttClient) onConnect(client paho.Client) {
 	}
 }
 
+// sendConnEvent records and sends ev, eventMu must be held so events are sent in the order the state changes.
+func (c *MqttClient) sendConnEvent(ev ConnEvent) {
+	c.stateMu.Lock()
+	c.state = ev.State
+	c.stateMu.Unlock()
+
+	select {
+	case c.connEvents <- ev:
+	case <-c.stop:
+	}
+}
+
+// checkTakeover wraps err in ErrSessionTakenOver if the broker keeps closing the connection right after connecting.
+func (c *MqttClient) checkTakeover(err error) error {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	// a connection lost before onConnect ran never got past subscribing
+	now := time.Now()
+	up := c.up
+	c.up = false
+	if !errors.Is(err, io.EOF) || (up && now.Sub(c.connectedAt) >= takeoverUptime) {
+		c.drops = c.drops[:0]
+		c.takeovers = 0
+		return err
+	}
+
+	c.drops = append(c.drops, now)
+	for len(c.drops) > 0 && now.Sub(c.drops[0]) > takeoverWindow {
+		c.drops = c.drops[1:]
+	}
+	if len(c.drops) < takeoverDrops {
+		return err
+	}
+
+	c.drops = c.drops[:0]
+	c.takeovers++
+	c.log.Warn("mqtt session taken over, another client is probably connected with the same client id", "reconnect_delay", c.reconnectDelay())
+	return fmt.Errorf("%w: %v", ErrSessionTakenOver, err)
+}
+
+// reconnectDelay returns how long to wait before reconnecting after the reported takeovers, stateMu must be held.
+func (c *MqttClient) reconnectDelay() time.Duration {
+	if c.takeovers == 0 {
+		return 0
+	}
+	return min(takeoverBackoff<<min(c.takeovers-1, 8), takeoverMaxBackoff)
+}
+
+// onReconnecting runs before every reconnect attempt of paho and holds it back after a takeover.
+func (c *MqttClient) onReconnecting(paho.Client, *paho.ClientOptions) {
+	c.stateMu.Lock()
+	delay := c.reconnectDelay()
+	c.stateMu.Unlock()
+
+	if delay == 0 {
+		return
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-c.stop:
+	}
+}
+
+// ClientID returns the MQTT client ID of the connection.
+func (c *MqttClient) ClientID() string {
+	return c.clientID
+}
+
+// newClientID returns prefix followed by a random suffix.
+func newClientID(prefix string) (string, error) {
+	if prefix == "" {
+		prefix = DefaultClientIDPrefix
+	}
+
+	var suffix [6]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		return "", err
+	}
+	return prefix + "-" + hex.EncodeToString(suffix[:]), nil
+}
+
+func (c *MqttClient) isReconnecting() bool {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	return c.state == StateReconnecting
+}
+
 func (c *MqttClient) handleMessage(_ paho.Client, msg paho.Message) {
+	c.log.Debug("mqtt message received", "topic", msg.Topic(), "size", len(msg.Payload()))
+	if c.config.Tap != nil {
+		c.config.Tap(true, msg.Topic(), msg.Payload())
+	}
+
 	select {
 	case <-c.stop:
 		return
 	case c.messageChan <- msg.Payload():
 	default:
-		// drop msg
+		c.log.Warn("mqtt message dropped, consumer is too slow", "topic", msg.Topic())
 	}
 }
 
@@ -123,6 +344,10 @@ func (c *MqttClient) Publish(ctx context.Context, cmd *protocol.Command) error {
 	}
 
 	topic := fmt.Sprintf("device/%s/request", c.config.SerialNumber)
+	c.log.Debug("mqtt publish", "topic", topic, "type", cmd.Type(), "command", cmd.Name(), "sequence_id", cmd.SequenceID())
+	if c.config.Tap != nil {
+		c.config.Tap(false, topic, json)
+	}
 
 	token := c.client.Publish(topic, qos, false, json)
 	return waitToken(ctx, c.stop, token)
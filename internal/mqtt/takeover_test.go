@@ -0,0 +1,79 @@
+package mqtt_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/emulator"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+func TestSessionTakeover(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	cfg := e.PrinterConfig()
+	taken := make(chan error, 1)
+	var drops atomic.Int32
+
+	// two clients sharing an ID kick each other off the broker on every reconnect
+	for range 2 {
+		c, err := mqtt.NewMqttClient(&mqtt.MqttConfig{
+			Host:         net.ParseIP("127.0.0.1"),
+			Port:         cfg.MQTTPort,
+			Username:     "bblp",
+			SerialNumber: cfg.SerialNumber,
+			AccessCode:   cfg.AccessCode,
+			ClientID:     "shared",
+		})
+		if err != nil {
+			t.Fatalf("new client: %v", err)
+		}
+		defer c.Close()
+
+		go func() {
+			last := mqtt.StateReconnecting
+			for ev := range c.ConnEvents() {
+				// reconnecting is reported with every lost connection, a connected right after it belongs to the lost one
+				if ev.State == mqtt.StateConnected && last == mqtt.StateDisconnected {
+					t.Error("connected reported after the connection was lost")
+				}
+				last = ev.State
+				if ev.State == mqtt.StateDisconnected {
+					drops.Add(1)
+				}
+				if errors.Is(ev.Err, mqtt.ErrSessionTakenOver) {
+					select {
+					case taken <- ev.Err:
+					default:
+					}
+				}
+			}
+		}()
+
+		if err := c.Connect(context.Background()); err != nil {
+			t.Fatalf("connect: %v", err)
+		}
+	}
+
+	select {
+	case <-taken:
+	case <-time.After(15 * time.Second):
+		t.Fatal("session takeover not reported")
+	}
+
+	// the client that reported the takeover backs off instead of kicking the other one off again
+	time.Sleep(500 * time.Millisecond)
+	settled := drops.Load()
+	time.Sleep(2 * time.Second)
+	if n := drops.Load(); n != settled {
+		t.Errorf("%d more connections lost after the takeover was reported, want none", n-settled)
+	}
+}
@@ -51,3 +51,20 @@ func (c *Command) Marshal() ([]byte, error) {
 		string(c.messageType): c.fields,
 	})
 }
+
+// Type returns the message type the command is sent as.
+func (c *Command) Type() MessageType {
+	return c.messageType
+}
+
+// Name returns the command field, e.g. "pushall", or an empty string if it is not set.
+func (c *Command) Name() string {
+	name, _ := c.fields["command"].(string)
+	return name
+}
+
+// SequenceID returns the sequence_id field the printer echoes in its reply.
+func (c *Command) SequenceID() string {
+	id, _ := c.fields["sequence_id"].(string)
+	return id
+}
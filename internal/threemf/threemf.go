@@ -0,0 +1,70 @@
+// Package threemf reads the parts of Bambu Studio .3mf project archives that the library cares about.
+package threemf
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+)
+
+var ErrPlateNotFound = errors.New("plate not found in 3mf archive")
+
+// Archive wraps a .3mf (zip) archive, plates are addressed by their 1-based index as used by Bambu Studio.
+type Archive struct {
+	zr *zip.Reader
+}
+
+func Open(r io.ReaderAt, size int64) (*Archive, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("open 3mf archive: %w", err)
+	}
+	return &Archive{zr: zr}, nil
+}
+
+// Plates returns the indices of all plates that have sliced G-code in the archive, in ascending order.
+func (a *Archive) Plates() []int {
+	var plates []int
+	for _, f := range a.zr.File {
+		var n int
+		if _, err := fmt.Sscanf(f.Name, "Metadata/plate_%d.gcode", &n); err == nil && f.Name == plateGcode(n) {
+			plates = append(plates, n)
+		}
+	}
+	slices.Sort(plates)
+	return plates
+}
+
+// PlateGcode opens the sliced G-code for plate n, the caller is responsible for closing it.
+func (a *Archive) PlateGcode(n int) (io.ReadCloser, error) {
+	return a.open(plateGcode(n))
+}
+
+// PlateThumbnail returns the PNG preview Bambu Studio renders for plate n.
+func (a *Archive) PlateThumbnail(n int) ([]byte, error) {
+	rc, err := a.open(fmt.Sprintf("Metadata/plate_%d.png", n))
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
+
+func (a *Archive) open(name string) (io.ReadCloser, error) {
+	f, err := a.zr.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrPlateNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func plateGcode(n int) string {
+	return fmt.Sprintf("Metadata/plate_%d.gcode", n)
+}
@@ -0,0 +1,282 @@
+package bambulabs_api
+
+import (
+	"encoding/hex"
+	"image/color"
+	"strconv"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// AMSType identifies the kind of AMS unit, the values match the lower nibble of the unit info field.
+type AMSType uint8
+
+const (
+	AMSTypeUnknown AMSType = iota
+	AMSTypeAMS
+	AMSTypeLite
+	AMSType2Pro
+	AMSTypeHT
+)
+
+func (t AMSType) String() string {
+	switch t {
+	case AMSTypeAMS:
+		return "AMS"
+	case AMSTypeLite:
+		return "AMS Lite"
+	case AMSType2Pro:
+		return "AMS 2 Pro"
+	case AMSTypeHT:
+		return "AMS HT"
+	default:
+		return "Unknown"
+	}
+}
+
+// AMSInventory is a decoded view of the AMS section of a printer report, replacing the hex bitfields with per slot values.
+type AMSInventory struct {
+	Units    []AMSUnit
+	External Slot
+
+	Settings AMSSettings
+
+	// AutoRefill reports whether the printer switches to a backup spool of the same filament when one runs out, BackupGroups lists the trays it considers interchangeable.
+	AutoRefill   bool
+	BackupGroups [][]TrayAddress
+
+	// ActiveTray is the printer wide ID of the loaded tray, [ExternalTrayID] for the external spool or [NoTrayID] if nothing is loaded.
+	ActiveTray int
+}
+
+// AMSUnit is a single AMS unit and its slots.
+type AMSUnit struct {
+	ID   int
+	Type AMSType
+
+	// Humidity is the humidity level (1-5) as shown on the printer, HumidityPercent is only reported by newer units and is -1 otherwise.
+	Humidity        int
+	HumidityPercent int
+	Temperature     float64
+
+	Drying DryingStatus
+
+	Slots []Slot
+}
+
+// DryingStatus is the drying state of an AMS unit, only AMS 2 Pro and AMS HT units can dry filament.
+type DryingStatus struct {
+	Active     bool
+	Remaining  time.Duration
+	TargetTemp float64
+	Filament   string
+}
+
+// Slot is a single tray of an AMS unit or the external spool.
+type Slot struct {
+	Address TrayAddress
+
+	Present   bool // a spool is inserted
+	BambuRFID bool // the spool has a Bambu Lab RFID tag
+	Reading   bool // the RFID tag is currently being read
+	ReadDone  bool // the RFID tag has been read
+	Active    bool // the slot is currently loaded into the toolhead
+
+	Type     string
+	Color    color.NRGBA
+	Diameter float64 // mm
+	Weight   float64 // spool net weight in grams, 0 if unknown
+
+	// Remain is the estimated remaining filament in percent, -1 if the printer has no estimate.
+	Remain int
+
+	TagUID   string
+	TrayUUID string
+}
+
+// Inventory returns the decoded AMS inventory from the last received state.
+func (p *printer) Inventory() (*AMSInventory, bool) {
+	state, ok := p.State()
+	if !ok {
+		return nil, false
+	}
+	return DecodeAMSInventory(state), true
+}
+
+// DecodeAMSInventory decodes the AMS section of a printer state as returned by [Printer.State].
+func DecodeAMSInventory(m *mqtt.Message) *AMSInventory {
+	ams := m.Print.Ams
+
+	bits := amsBits{
+		units:    parseBits(ams.AmsExistBits),
+		exist:    parseBits(ams.TrayExistBits),
+		bbl:      parseBits(ams.TrayIsBblBits),
+		reading:  parseBits(ams.TrayReadingBits),
+		readDone: parseBits(ams.TrayReadDoneBits),
+	}
+
+	inv := &AMSInventory{
+		Settings: AMSSettings{
+			StartupRead:     ams.PowerOnFlag,
+			ReadOnInsert:    ams.InsertFlag,
+			CalibrateRemain: ams.CalibrateRemainFlag,
+		},
+		AutoRefill: m.Print.HomeFlag&homeFlagAutoSwitchFilament != 0,
+		ActiveTray: parseTrayID(ams.TrayNow),
+	}
+
+	for _, group := range m.Print.FilamBak {
+		inv.BackupGroups = append(inv.BackupGroups, decodeBackupGroup(bitfield(group)))
+	}
+
+	for _, u := range ams.Ams {
+		id, err := strconv.Atoi(u.ID)
+		if err != nil {
+			continue
+		}
+		// Firmware keeps reporting disconnected units, ams_exist_bits lists the ones actually attached. AMS HT units are not part of it.
+		if ams.AmsExistBits != "" && id < amsHTFirstID && !bits.units.has(id) {
+			continue
+		}
+
+		unit := AMSUnit{
+			ID:              id,
+			Type:            decodeAMSType(id, u.Info),
+			Humidity:        atoiOr(u.Humidity, 0),
+			HumidityPercent: atoiOr(u.HumidityRaw, -1),
+			Temperature:     atofOr(u.Temp, 0),
+			Drying:          decodeDrying(u),
+		}
+
+		for _, t := range u.Tray {
+			trayID, err := strconv.Atoi(t.ID)
+			if err != nil {
+				continue
+			}
+			global, err := globalTrayID(id, trayID)
+			if err != nil {
+				continue
+			}
+
+			slot := decodeSlot(TrayAddress{AMS: id, Tray: trayID}, t)
+			slot.Active = global == inv.ActiveTray
+
+			// AMS HT units are not part of the tray bitfields.
+			if id < amsHTFirstID {
+				slot.Present = bits.exist.has(global)
+				slot.BambuRFID = bits.bbl.has(global)
+				slot.Reading = bits.reading.has(global)
+				slot.ReadDone = bits.readDone.has(global)
+			}
+
+			unit.Slots = append(unit.Slots, slot)
+		}
+
+		inv.Units = append(inv.Units, unit)
+	}
+
+	inv.External = decodeSlot(ExternalSpool, m.Print.VtTray)
+	inv.External.Active = inv.ActiveTray == ExternalTrayID
+
+	return inv
+}
+
+// decodeBackupGroup converts a tray bitmask from filam_bak into tray addresses.
+func decodeBackupGroup(b bitfield) []TrayAddress {
+	var trays []TrayAddress
+	for id := range amsHTFirstID {
+		if b.has(id) {
+			trays = append(trays, TrayAddress{AMS: id / traysPerAMS, Tray: id % traysPerAMS})
+		}
+	}
+	return trays
+}
+
+// decodeSlot decodes the fields reported on the tray itself, presence and RFID state are derived from the tray contents and overridden by the bitfields where available.
+func decodeSlot(addr TrayAddress, t mqtt.Tray) Slot {
+	return Slot{
+		Address:   addr,
+		Present:   t.TrayType != "",
+		BambuRFID: t.TagUID != "" && t.TagUID != "0000000000000000",
+		Type:      t.TrayType,
+		Color:     parseColor(t.TrayColor),
+		Diameter:  atofOr(t.TrayDiameter, 0),
+		Weight:    atofOr(t.TrayWeight, 0),
+		Remain:    t.Remain,
+		TagUID:    t.TagUID,
+		TrayUUID:  t.TrayUUID,
+	}
+}
+
+func decodeDrying(u mqtt.AMSUnit) DryingStatus {
+	status := DryingStatus{
+		Active:    u.DryTime > 0,
+		Remaining: time.Duration(u.DryTime) * time.Minute,
+	}
+	if u.DrySetting != nil && status.Active {
+		status.TargetTemp = float64(u.DrySetting.DryTemperature)
+		status.Filament = u.DrySetting.DryFilament
+	}
+	return status
+}
+
+// decodeAMSType decodes the unit type from the lower nibble of the info field (1 AMS, 2 AMS Lite, 3 AMS 2 Pro, 4 AMS HT),
+// falling back to the ID range for firmware that does not report it. Unrecognized values decode to [AMSTypeUnknown].
+func decodeAMSType(id int, info string) AMSType {
+	if info != "" {
+		v, err := strconv.ParseUint(info, 16, 32)
+		if t := AMSType(v & 0x0F); err == nil && t >= AMSTypeAMS && t <= AMSTypeHT {
+			return t
+		}
+		return AMSTypeUnknown
+	}
+	if id >= amsHTFirstID {
+		return AMSTypeHT
+	}
+	return AMSTypeAMS
+}
+
+type bitfield uint64
+
+func (b bitfield) has(i int) bool {
+	return i >= 0 && i < 64 && b&(1<<i) != 0
+}
+
+type amsBits struct {
+	units                         bitfield
+	exist, bbl, reading, readDone bitfield
+}
+
+func parseBits(s string) bitfield {
+	v, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0
+	}
+	return bitfield(v)
+}
+
+// parseColor parses RRGGBBAA colors as reported in tray_color, the alpha is not premultiplied.
+func parseColor(s string) color.NRGBA {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 4 {
+		return color.NRGBA{}
+	}
+	return color.NRGBA{R: b[0], G: b[1], B: b[2], A: b[3]}
+}
+
+func atoiOr(s string, fallback int) int {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+func atofOr(s string, fallback float64) float64 {
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
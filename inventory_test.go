@@ -0,0 +1,144 @@
+package bambulabs_api
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+func TestDecodeAMSInventory(t *testing.T) {
+	var m mqtt.Message
+	m.Print.Ams = mqtt.AMS{
+		AmsExistBits:     "3",
+		TrayExistBits:    "30", // AMS 1 trays 0 and 1
+		TrayIsBblBits:    "10",
+		TrayReadDoneBits: "30",
+		TrayNow:          "5",
+		Ams: []mqtt.AMSUnit{
+			{ID: "0", Humidity: "4", Temp: "23.5", Tray: []mqtt.Tray{{ID: "0"}, {ID: "1"}, {ID: "2"}, {ID: "3"}}},
+			{ID: "1", Info: "2003", Humidity: "2", HumidityRaw: "31", Temp: "40.1", Tray: []mqtt.Tray{
+				{ID: "0", TrayType: "PLA", TrayColor: "FF8000FF", Remain: 80},
+				{ID: "1", TrayType: "PETG", TrayColor: "000000FF", Remain: -1},
+				{ID: "2"},
+				{ID: "3"},
+			}},
+			{ID: "128", Info: "1004", Humidity: "5", Temp: "25.0", Tray: []mqtt.Tray{{ID: "0", TrayType: "PA-CF", TagUID: "A1B2C3D4E5F60708"}}},
+		},
+	}
+	m.Print.Ams.InsertFlag = true
+	m.Print.Ams.CalibrateRemainFlag = true
+	m.Print.HomeFlag = 1<<10 | 1
+	m.Print.FilamBak = mqtt.FilamentBackup{0x30}
+	m.Print.VtTray = mqtt.Tray{ID: "254"}
+
+	inv := DecodeAMSInventory(&m)
+
+	if len(inv.Units) != 3 {
+		t.Fatalf("len(Units) = %d, want 3", len(inv.Units))
+	}
+	if inv.ActiveTray != 5 {
+		t.Errorf("ActiveTray = %d, want 5", inv.ActiveTray)
+	}
+
+	pro := inv.Units[1]
+	if pro.Type != AMSType2Pro || pro.HumidityPercent != 31 || pro.Temperature != 40.1 {
+		t.Errorf("unit 1 = %+v, want AMS 2 Pro at 31%% and 40.1°C", pro)
+	}
+
+	want := Slot{
+		Address:   TrayAddress{AMS: 1, Tray: 0},
+		Present:   true,
+		BambuRFID: true,
+		ReadDone:  true,
+		Type:      "PLA",
+		Color:     color.NRGBA{R: 0xFF, G: 0x80, B: 0x00, A: 0xFF},
+		Remain:    80,
+	}
+	if got := pro.Slots[0]; got != want {
+		t.Errorf("slot 1/0 = %+v, want %+v", got, want)
+	}
+	if got := pro.Slots[1]; !got.Present || got.BambuRFID || !got.Active {
+		t.Errorf("slot 1/1 = %+v, want present, active, without RFID", got)
+	}
+	if inv.Units[0].Slots[0].Present {
+		t.Errorf("slot 0/0 reported present, bitfield says empty")
+	}
+
+	ht := inv.Units[2]
+	if ht.Type != AMSTypeHT || !ht.Slots[0].Present || !ht.Slots[0].BambuRFID {
+		t.Errorf("AMS HT = %+v, want a present RFID spool", ht)
+	}
+
+	if want := (AMSSettings{ReadOnInsert: true, CalibrateRemain: true}); inv.Settings != want {
+		t.Errorf("Settings = %+v, want %+v", inv.Settings, want)
+	}
+	if !inv.AutoRefill {
+		t.Errorf("AutoRefill = false, want true")
+	}
+	if want := [][]TrayAddress{{{AMS: 1, Tray: 0}, {AMS: 1, Tray: 1}}}; !reflect.DeepEqual(inv.BackupGroups, want) {
+		t.Errorf("BackupGroups = %v, want %v", inv.BackupGroups, want)
+	}
+
+	if inv.External.Present || inv.External.Address != ExternalSpool {
+		t.Errorf("External = %+v, want an empty external spool", inv.External)
+	}
+}
+
+func TestDecodeAMSType(t *testing.T) {
+	tests := []struct {
+		id   int
+		info string
+		want AMSType
+	}{
+		{0, "1001", AMSTypeAMS},
+		{0, "2002", AMSTypeLite},
+		{1, "2003", AMSType2Pro},
+		{128, "1004", AMSTypeHT},
+		{0, "", AMSTypeAMS},
+		{128, "", AMSTypeHT},
+		{0, "1000", AMSTypeUnknown},
+		{0, "1007", AMSTypeUnknown},
+		{0, "invalid", AMSTypeUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := decodeAMSType(tt.id, tt.info); got != tt.want {
+			t.Errorf("decodeAMSType(%d, %q) = %s, want %s", tt.id, tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeAMSInventoryDetachedUnits(t *testing.T) {
+	var m mqtt.Message
+	m.Print.Ams = mqtt.AMS{
+		AmsExistBits: "5", // units 0 and 2
+		Ams: []mqtt.AMSUnit{
+			{ID: "0", Info: "1001"},
+			{ID: "1", Info: "1001"},
+			{ID: "2", Info: "1003"},
+			{ID: "128", Info: "1004"},
+		},
+	}
+
+	var ids []int
+	for _, u := range DecodeAMSInventory(&m).Units {
+		ids = append(ids, u.ID)
+	}
+	if want := []int{0, 2, 128}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("unit IDs = %v, want %v", ids, want)
+	}
+}
+
+func TestParseColorTranslucent(t *testing.T) {
+	got := parseColor("FF000080")
+	if want := (color.NRGBA{R: 0xFF, A: 0x80}); got != want {
+		t.Errorf("parseColor() = %+v, want %+v", got, want)
+	}
+
+	// converting to premultiplied alpha scales the channels
+	if r, _, _, a := got.RGBA(); r != 0x8080 || a != 0x8080 {
+		t.Errorf("RGBA() = %#x, %#x, want premultiplied red", r, a)
+	}
+}
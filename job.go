@@ -0,0 +1,64 @@
+package bambulabs_api
+
+import (
+	"fmt"
+
+	"github.com/torbenconto/bambulabs_api/gcode"
+)
+
+// buildVolumeTolerance allows for slicer rounding at the edges of the plate.
+const buildVolumeTolerance = 1.0 // mm
+
+// CheckJob validates a [gcode.Analysis] against the known limits of a given [Model] before the job is uploaded.
+// It returns an [ErrExceedsBuildVolume] or [ErrExceedsTemperature] describing the first violated limit, limits that are unknown for a model are skipped.
+func CheckJob(m Model, a *gcode.Analysis) error {
+	limits := models[m].Limits
+
+	volume := limits.BuildVolume
+	if limits.DualNozzleBuildVolume != (Volume{}) && toolsUsed(a) > 1 {
+		volume = limits.DualNozzleBuildVolume
+	}
+
+	if a.LayerCount() > 0 {
+		if err := checkAxis("x", a.Bounds.Min.X, a.Bounds.Max.X, volume.X); err != nil {
+			return err
+		}
+		if err := checkAxis("y", a.Bounds.Min.Y, a.Bounds.Max.Y, volume.Y); err != nil {
+			return err
+		}
+		if err := checkAxis("z", a.Bounds.Min.Z, a.Bounds.Max.Z, volume.Z); err != nil {
+			return err
+		}
+	}
+
+	if limits.MaxNozzleTemp > 0 && a.MaxNozzleTemp > limits.MaxNozzleTemp {
+		return fmt.Errorf("%w: nozzle %.0f°C > %.0f°C", ErrExceedsTemperature, a.MaxNozzleTemp, limits.MaxNozzleTemp)
+	}
+	if limits.MaxBedTemp > 0 && a.MaxBedTemp > limits.MaxBedTemp {
+		return fmt.Errorf("%w: bed %.0f°C > %.0f°C", ErrExceedsTemperature, a.MaxBedTemp, limits.MaxBedTemp)
+	}
+
+	return nil
+}
+
+// toolsUsed counts the tools that extrude filament. Tools are not mapped to nozzles in the G-code, so a job using several
+// tools on a dual nozzle model is conservatively checked against the envelope both nozzles reach.
+func toolsUsed(a *gcode.Analysis) int {
+	n := 0
+	for _, length := range a.Filament {
+		if length > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+func checkAxis(axis string, lo, hi, size float64) error {
+	if size <= 0 {
+		return nil
+	}
+	if lo < -buildVolumeTolerance || hi > size+buildVolumeTolerance {
+		return fmt.Errorf("%w: %s spans %.1f to %.1f mm, limit is %.0f mm", ErrExceedsBuildVolume, axis, lo, hi, size)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package bambulabs_api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api/gcode"
+)
+
+func TestCheckJob(t *testing.T) {
+	fits := &gcode.Analysis{
+		Layers:        []gcode.Layer{{Z: 0.2, Height: 0.2}},
+		Bounds:        gcode.Bounds{Min: gcode.Point{X: 10, Y: 10, Z: 0.2}, Max: gcode.Point{X: 170, Y: 170, Z: 0.2}},
+		MaxNozzleTemp: 220,
+		MaxBedTemp:    60,
+	}
+
+	tests := []struct {
+		name  string
+		model Model
+		edit  func(a *gcode.Analysis)
+		want  error
+	}{
+		{name: "fits", model: ModelA1Mini},
+		{name: "too wide", model: ModelA1Mini, edit: func(a *gcode.Analysis) { a.Bounds.Max.X = 200 }, want: ErrExceedsBuildVolume},
+		{name: "too hot", model: ModelA1Mini, edit: func(a *gcode.Analysis) { a.MaxBedTemp = 90 }, want: ErrExceedsTemperature},
+		{name: "single nozzle", model: ModelH2D, edit: func(a *gcode.Analysis) { a.Bounds.Max.X = 320; a.Filament = map[int]float64{0: 100} }},
+		{name: "single nozzle too wide", model: ModelH2D, edit: func(a *gcode.Analysis) { a.Bounds.Max.X = 340 }, want: ErrExceedsBuildVolume},
+		{name: "dual nozzle", model: ModelH2D, edit: func(a *gcode.Analysis) { a.Bounds.Max.X = 290; a.Filament = map[int]float64{0: 100, 1: 50} }},
+		{name: "dual nozzle too wide", model: ModelH2DPro, edit: func(a *gcode.Analysis) {
+			a.Bounds.Max.X = 320
+			a.Filament = map[int]float64{0: 100, 1: 50}
+			a.ToolChanges = 4
+		}, want: ErrExceedsBuildVolume},
+		{name: "unknown model skips limits", model: ModelUnknown, edit: func(a *gcode.Analysis) { a.Bounds.Max.X = 1000 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := *fits
+			if tt.edit != nil {
+				tt.edit(&a)
+			}
+			if err := CheckJob(tt.model, &a); !errors.Is(err, tt.want) {
+				t.Fatalf("CheckJob() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,29 +1,51 @@
 package bambulabs_api
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"net"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/torbenconto/bambulabs_api/hms"
 	"github.com/torbenconto/bambulabs_api/internal/ftp"
 	"github.com/torbenconto/bambulabs_api/internal/mqtt"
 	"github.com/torbenconto/bambulabs_api/internal/protocol"
+	"github.com/torbenconto/bambulabs_api/recording"
 )
 
 // defaultOpTimeout is applied when a caller does not provide a deadline.
 const defaultOpTimeout time.Duration = 10 * time.Second
 
+// defaultFilamentChangeTimeout is applied to AMS operations that physically move filament, which can take minutes.
+const defaultFilamentChangeTimeout time.Duration = 5 * time.Minute
+
+// maxReportSize bounds accepted reports, full reports with several AMS units are a few tens of KB so anything larger is corrupt.
+const maxReportSize = 1 << 20
+
+// statePollInterval is how often operations waiting for the printer to confirm a change check the state.
+const statePollInterval time.Duration = 250 * time.Millisecond
+
 func withDefaultOpTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withDefaultTimeout(ctx, defaultOpTimeout)
+}
+
+func withDefaultTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
 	if _, ok := ctx.Deadline(); ok {
 		return context.WithCancel(ctx)
 	}
-	return context.WithTimeout(ctx, defaultOpTimeout)
+	return context.WithTimeout(ctx, timeout)
 }
 
 // Config represents configuration options for a given [Printer], changing the MQTT and FTP ports is not recommended for inexperienced users (mostly used for testing purposes with the emulator).
@@ -35,6 +57,26 @@ type Config struct {
 
 	AccessCode   string
 	SerialNumber string
+
+	// Cloud, if set, connects through the Bambu Cloud broker, Host, ports and AccessCode are then unused.
+	Cloud *CloudConfig
+
+	// ClientIDPrefix starts the MQTT client ID, which always ends in a random suffix so several processes can connect to the same printer.
+	// Empty uses "bambulabs_api".
+	ClientIDPrefix string
+
+	// TLS configures how the printers certificate is checked, by default it is pinned on first use.
+	// In cloud mode the brokers certificate is verified against RootCAs or the system roots unless the mode is TLSInsecure.
+	TLS TLSConfig
+
+	// StaleAfter is how long the printer may stay silent before its state is marked stale, zero uses a window suited to Model.
+	StaleAfter time.Duration
+
+	// Logger receives the printers logs with its serial number and model attached, nil uses the [Client]'s logger or [slog.Default].
+	Logger *slog.Logger
+
+	// Recorder, if set, records all MQTT traffic with the serial number and access code redacted, see [recording].
+	Recorder *recording.Recorder
 }
 
 // Printer represents a connection to any and all BambuLabs printers, the primary [Client] struct holds objects that satisfy this interface.
@@ -42,6 +84,10 @@ type Printer interface {
 	Serial() string
 	Close() error
 	State() (*mqtt.Message, bool)
+	Inventory() (*AMSInventory, bool)
+	PrintError() (*hms.PrintError, bool)
+	Events() <-chan Event
+	ConnectionStatus() ConnectionStatus
 
 	RequestUpdate(ctx context.Context) error
 
@@ -50,16 +96,33 @@ type Printer interface {
 	SetFan(ctx context.Context, fan Fan, speed uint8) error
 	SendGcode(ctx context.Context, input []string) error
 
+	LoadFilament(ctx context.Context, amsID, trayID int) error
+	LoadExternalSpool(ctx context.Context) error
+	UnloadFilament(ctx context.Context) error
+	SetTrayFilament(ctx context.Context, tray TrayAddress, f FilamentSetting) error
+	StartDrying(ctx context.Context, amsID int, s DryingSettings) error
+	StopDrying(ctx context.Context, amsID int) error
+	SetAMSSettings(ctx context.Context, s AMSSettings) error
+	SetAutoRefill(ctx context.Context, enabled bool) error
+
+	ClearError(ctx context.Context, err error) error
+	ResumeAfterError(ctx context.Context, err error) error
+
 	ListFiles(path string) ([]os.FileInfo, error)
 	DownloadFile(path string, w io.Writer) error
 	UploadFile(path string, r io.Reader) error
 	DeleteFile(path string) error
+
+	Thumbnail(path string, plate int) ([]byte, error)
+	CurrentThumbnail() ([]byte, error)
 }
 
 type printer struct {
 	cfg Config // own the config
+	log *slog.Logger
 
 	// Cancellation tree for entire printer object
+	ctx    context.Context
 	cancel context.CancelFunc
 
 	mqtt *mqtt.MqttClient
@@ -68,14 +131,59 @@ type printer struct {
 	// Hot-swappable pointer to the current mqtt state
 	// May represent some leakage of information but neccessary in order to simply state access mechanisms
 	state atomic.Pointer[mqtt.Message]
+	// report is the print object merged from all status reports, models such as the P1 only send changed fields. Only accessed by the state loop.
+	report map[string]any
+
+	thumbnails thumbnailCache
+
+	events chan Event
+	done   chan struct{}
+
+	connMu sync.Mutex
+	conn   ConnectionStatus
+}
 
-	done chan struct{}
+// logger returns the logger of cfg with the printers attributes attached.
+func (cfg Config) logger() *slog.Logger {
+	l := cfg.Logger
+	if l == nil {
+		l = slog.Default()
+	}
+	return l.With("serial", cfg.SerialNumber, "model", cfg.Model.String())
+}
+
+// recordTap returns an MQTT tap writing to cfg.Recorder, or nil if recording is disabled.
+func recordTap(cfg Config, logger *slog.Logger) mqtt.Tap {
+	if cfg.Recorder == nil {
+		return nil
+	}
+
+	return func(incoming bool, topic string, payload []byte) {
+		dir := recording.Out
+		if incoming {
+			dir = recording.In
+		}
+		secrets := []recording.Secret{
+			{Value: cfg.SerialNumber, Placeholder: recording.RedactedSerial},
+			{Value: cfg.AccessCode, Placeholder: recording.RedactedAccessCode},
+		}
+		if cfg.Cloud != nil {
+			secrets = append(secrets,
+				recording.Secret{Value: cfg.Cloud.UserID, Placeholder: recording.RedactedUserID},
+				recording.Secret{Value: cfg.Cloud.Token, Placeholder: recording.RedactedToken},
+			)
+		}
+		if err := cfg.Recorder.Record(dir, topic, payload, secrets...); err != nil {
+			logger.Error("failed to record mqtt message", "topic", topic, "error", err)
+		}
+	}
 }
 
 // NewPrinter creates a new [printer] object and attempts both an MQTT and FTP connection using provided options
 // If the MQTT connection fails, the construction fails. If the FTP fails, construction will succeed but remain in a degraded state.
 func NewPrinter(parent context.Context, cfg Config) (*printer, error) {
 	ctx, cancel := context.WithCancel(parent)
+	logger := cfg.logger()
 
 	// Assign default ports if none provided.
 	mqttPort := cfg.MQTTPort
@@ -88,13 +196,34 @@ func NewPrinter(parent context.Context, cfg Config) (*printer, error) {
 		ftpPort = 990
 	}
 
-	mc, err := mqtt.NewMqttClient(&mqtt.MqttConfig{
-		Host:         cfg.Host,
-		Port:         mqttPort,
-		SerialNumber: cfg.SerialNumber,
-		Username:     "bblp",
-		AccessCode:   cfg.AccessCode,
-	})
+	mqttCfg := &mqtt.MqttConfig{
+		SerialNumber:   cfg.SerialNumber,
+		ClientIDPrefix: cfg.ClientIDPrefix,
+		Tap:            recordTap(cfg, logger),
+		Logger:         logger,
+	}
+
+	var tlsCfg *tls.Config
+	if cfg.Cloud != nil {
+		if err := cfg.Cloud.mqttConfig(mqttCfg, cfg.TLS); err != nil {
+			cancel()
+			return nil, err
+		}
+	} else {
+		var err error
+		if tlsCfg, err = cfg.TLS.clientTLS(cfg.SerialNumber); err != nil {
+			cancel()
+			return nil, err
+		}
+
+		mqttCfg.Host = cfg.Host
+		mqttCfg.Port = mqttPort
+		mqttCfg.Username = "bblp"
+		mqttCfg.AccessCode = cfg.AccessCode
+		mqttCfg.TLS = tlsCfg
+	}
+
+	mc, err := mqtt.NewMqttClient(mqttCfg)
 
 	// MQTT connection is vital for printer communication so we'll deconstruct the entire object if it fails.
 	if err != nil {
@@ -106,32 +235,41 @@ func NewPrinter(parent context.Context, cfg Config) (*printer, error) {
 		return nil, err
 	}
 
-	fc := ftp.NewFtpClient(&ftp.FtpClientConfig{
-		Host:       cfg.Host.String(),
-		Port:       ftpPort,
-		Username:   "bblp",
-		AccessCode: cfg.AccessCode,
-	})
-
-	// FTP is non-vital so we'll warn the user and proceed without FTP connection.
-	if err := fc.Connect(ctx); err != nil {
-		log.Printf("[%s] ftp connect failed, continuing without file access: %v", cfg.SerialNumber, err)
-		fc = nil
+	// FTP is non-vital so we'll warn the user and proceed without FTP connection. The cloud does not offer file access.
+	var fc *ftp.FtpClient
+	if cfg.Cloud == nil {
+		fc = ftp.NewFtpClient(&ftp.FtpClientConfig{
+			Host:       cfg.Host.String(),
+			Port:       ftpPort,
+			Username:   "bblp",
+			AccessCode: cfg.AccessCode,
+			TLS:        tlsCfg,
+		})
+
+		if err := fc.Connect(ctx); err != nil {
+			logger.Warn("ftp connect failed, continuing without file access", "error", err)
+			fc = nil
+		}
 	}
 
 	p := &printer{
 		cfg: cfg,
+		log: logger,
 
 		mqtt: mc,
 		ftp:  fc,
 
+		events: make(chan Event, eventBufferSize),
 		done:   make(chan struct{}),
+		ctx:    ctx,
 		cancel: cancel,
 	}
 
 	if err := p.mqtt.WaitConnected(ctx); err != nil {
 		_ = mc.Close()
-		_ = fc.Close()
+		if fc != nil {
+			_ = fc.Close()
+		}
 		return nil, err
 	}
 
@@ -143,9 +281,19 @@ func NewPrinter(parent context.Context, cfg Config) (*printer, error) {
 
 func (p *printer) run(ctx context.Context) {
 	messageChan := p.mqtt.MessageChan()
+	connEvents := p.mqtt.ConnEvents()
+
+	staleAfter := p.cfg.StaleAfter
+	if staleAfter == 0 {
+		staleAfter = staleWindow(p.cfg.Model)
+	}
 
 	go func() {
 		defer close(p.done)
+		defer close(p.events) // only the state loop sends events
+
+		stale := time.NewTimer(staleAfter)
+		defer stale.Stop()
 
 		for {
 			select {
@@ -155,10 +303,18 @@ func (p *printer) run(ctx context.Context) {
 			case <-p.mqtt.Done():
 				return
 
+			case ev := <-connEvents:
+				p.handleConnEvent(ev)
+
+			case <-stale.C:
+				p.markStale()
+
 			case payload, ok := <-messageChan:
 				if !ok {
 					return
 				}
+				p.messageReceived()
+				stale.Reset(staleAfter)
 				p.updateState(payload)
 			}
 		}
@@ -171,15 +327,80 @@ func (p *printer) publish(ctx context.Context, cmd *protocol.Command) error {
 }
 
 // updateState takes a raw MQTT payload and attempts to convert it into a [import/mqtt.Message].
+// Reports are merged into the previous state since some models only send the fields that changed.
 // Failure is not fatal but may represent something severly wrong with the message struct itself.
 func (p *printer) updateState(payload []byte) {
+	if len(payload) > maxReportSize {
+		p.log.Warn("dropping oversized mqtt payload", "size", len(payload))
+		return
+	}
+	if !isStatusReport(payload) {
+		return
+	}
+
+	// decode on its own first so that a malformed report is dropped instead of poisoning the merged state
+	if err := json.Unmarshal(payload, &mqtt.Message{}); err != nil {
+		p.log.Warn("failed to unmarshal mqtt payload", "error", err)
+		return
+	}
+
+	var report struct {
+		Print map[string]any `json:"print"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber() // keep integers such as print_error exact
+	if err := dec.Decode(&report); err != nil {
+		p.log.Warn("failed to unmarshal mqtt payload", "error", err)
+		return
+	}
+
+	if p.report == nil {
+		p.report = make(map[string]any)
+	}
+	mergeReport(p.report, report.Print)
+
+	merged, err := json.Marshal(p.report)
+	if err != nil {
+		p.log.Error("failed to merge mqtt payload", "error", err)
+		return
+	}
+
 	var msg mqtt.Message
-	if err := json.Unmarshal(payload, &msg); err != nil {
-		log.Printf("[%s] failed to unmarshal MQTT payload: %v", p.cfg.SerialNumber, err)
+	if err := json.Unmarshal(merged, &msg.Print); err != nil {
+		p.log.Error("failed to unmarshal merged state", "error", err)
 		return
 	}
 
-	p.state.Store(&msg)
+	prev := p.state.Swap(&msg)
+	p.emitErrorEvents(prev, &msg)
+}
+
+// mergeReport merges a (partial) print object into dst, nested objects are merged while all other values, including arrays, replace the previous value.
+func mergeReport(dst, src map[string]any) {
+	for k, v := range src {
+		if next, ok := v.(map[string]any); ok {
+			if prev, ok := dst[k].(map[string]any); ok {
+				mergeReport(prev, next)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// isStatusReport reports whether payload is a status push, printers also answer commands on the report topic and those replies must not replace the state.
+// Payloads that fail to decode are reported as status so that updateState logs them.
+func isStatusReport(payload []byte) bool {
+	var probe struct {
+		Print *struct {
+			Command string `json:"command"`
+		} `json:"print"`
+	}
+	if err := json.Unmarshal(payload, &probe); err != nil {
+		return true
+	}
+
+	return probe.Print != nil && (probe.Print.Command == "" || probe.Print.Command == "push_status")
 }
 
 // RequestUpdate manually requests a "pushall", updating the printer state. Exercise caution in the interval you use this, especially on lower end printers.
@@ -201,6 +422,10 @@ func (p *printer) Close() error {
 
 	<-p.done
 
+	p.connMu.Lock()
+	p.conn.State, p.conn.LastError = Disconnected, ErrPrinterClosed
+	p.connMu.Unlock()
+
 	mqttErr := p.mqtt.Close()
 
 	var ftpErr error
@@ -223,6 +448,37 @@ func (p *printer) State() (*mqtt.Message, bool) {
 	return m, true
 }
 
+// PrintError returns the decoded device error (print_error) of the last received state, the boolean is false if there is no state or no error.
+func (p *printer) PrintError() (*hms.PrintError, bool) {
+	m, ok := p.State()
+	if !ok {
+		return nil, false
+	}
+
+	e := printError(m)
+	return e, e != nil
+}
+
+// waitState blocks until cond is satisfied by the current state, ctx is done or the printer is closed.
+func (p *printer) waitState(ctx context.Context, cond func(*mqtt.Message) bool) error {
+	ticker := time.NewTicker(statePollInterval)
+	defer ticker.Stop()
+
+	for {
+		if m, ok := p.State(); ok && cond(m) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.done:
+			return ErrPrinterClosed
+		case <-ticker.C:
+		}
+	}
+}
+
 // files (FTP)
 
 // ListFiles calls the underlying FTP client to fetch files found on the printer, returns an [ErrFTPUnavalible] if FTP is unavalible.
@@ -326,6 +582,459 @@ func (p *printer) SetFan(ctx context.Context, fan Fan, speed uint8) error { // i
 
 // end fans
 
+// begin ams
+
+// LoadFilament publishes an ams_change_filament command loading the filament in a given AMS tray (0-based AMS and tray index, AMS HT units use their ID with tray 0) and waits for the printer to report it in tray_tar and tray_now.
+// Filament changes can take minutes, a default timeout of 5 minutes is applied when ctx has no deadline.
+// If the [Printer] has no AMS, an [ErrAmsNotSupported] will be returned.
+func (p *printer) LoadFilament(ctx context.Context, amsID, trayID int) error {
+	target, err := globalTrayID(amsID, trayID)
+	if err != nil {
+		return err
+	}
+
+	return p.changeFilament(ctx, amsID, trayID, target)
+}
+
+// LoadExternalSpool switches to the external spool (vt_tray), unloading any AMS filament first, and waits for the printer to confirm the change.
+// If the [Printer] has no AMS, an [ErrAmsNotSupported] will be returned.
+func (p *printer) LoadExternalSpool(ctx context.Context) error {
+	return p.changeFilament(ctx, NoTrayID, 0, ExternalTrayID)
+}
+
+// UnloadFilament unloads the currently loaded filament and waits for the printer to report an empty toolhead.
+// If the [Printer] has no AMS, an [ErrAmsNotSupported] will be returned.
+func (p *printer) UnloadFilament(ctx context.Context) error {
+	return p.changeFilament(ctx, NoTrayID, NoTrayID, NoTrayID)
+}
+
+func (p *printer) changeFilament(ctx context.Context, amsID, slotID, target int) error {
+	ctx, cancel := withDefaultTimeout(ctx, defaultFilamentChangeTimeout)
+	defer cancel()
+
+	if !models[p.cfg.Model].Capabilities.Has(CapabilityAnyAms) {
+		return ErrAmsNotSupported
+	}
+
+	currentTemp, targetTemp := 0.0, float64(defaultFilamentTemp)
+	if state, ok := p.State(); ok {
+		if now, ok := reportedTrayID(state.Print.Ams.TrayNow); ok && now == target {
+			return nil // already loaded
+		}
+
+		currentTemp = state.Print.NozzleTemper
+		if tray, ok := findTray(state, target); ok {
+			if t, err := strconv.ParseFloat(tray.NozzleTempMax, 64); err == nil && t > 0 {
+				targetTemp = t
+			}
+		}
+	}
+
+	if err := p.publish(ctx, newChangeFilamentCommand(amsID, slotID, target, currentTemp, targetTemp)); err != nil {
+		return fmt.Errorf("error changing filament to tray %d: %w", target, err)
+	}
+
+	// tray_tar reports the target of the change in progress, tray_now the tray loaded once it completes.
+	if err := p.waitState(ctx, func(m *mqtt.Message) bool {
+		tar, okTar := reportedTrayID(m.Print.Ams.TrayTar)
+		now, okNow := reportedTrayID(m.Print.Ams.TrayNow)
+		return okTar && okNow && tar == target && now == target
+	}); err != nil {
+		return fmt.Errorf("waiting for tray %d: %w", target, err)
+	}
+
+	return nil
+}
+
+func newChangeFilamentCommand(amsID, slotID, target int, currentTemp, targetTemp float64) *protocol.Command {
+	return protocol.NewCommand(protocol.Print).
+		WithCommand("ams_change_filament").
+		Set("target", target).
+		Set("ams_id", amsID).
+		Set("slot_id", slotID).
+		Set("curr_temp", int(currentTemp)).
+		Set("tar_temp", int(targetTemp))
+}
+
+// SetTrayFilament publishes an ams_filament_setting command (and extrusion_cali_sel if a calibration profile is chosen) describing the filament in a given tray,
+// then requests an update and waits for the tray to report the new type and color.
+// If the [Printer] has no AMS, an [ErrAmsNotSupported] will be returned for AMS trays, the external spool can always be configured.
+// An [ErrInvalidFilament] is returned if f has an invalid color or a temperature range outside of the models limits.
+func (p *printer) SetTrayFilament(ctx context.Context, tray TrayAddress, f FilamentSetting) error {
+	ctx, cancel := withDefaultOpTimeout(ctx)
+	defer cancel()
+
+	if !tray.IsExternal() && !models[p.cfg.Model].Capabilities.Has(CapabilityAnyAms) {
+		return ErrAmsNotSupported
+	}
+
+	id, err := tray.ID()
+	if err != nil {
+		return err
+	}
+
+	color, err := f.validate(p.cfg.Model)
+	if err != nil {
+		return err
+	}
+
+	if err := p.publish(ctx, newFilamentSettingCommand(tray, f, color)); err != nil {
+		return fmt.Errorf("error setting filament for tray %d: %w", id, err)
+	}
+
+	if f.CalibrationIndex != nil {
+		nozzleDiameter := ""
+		if state, ok := p.State(); ok {
+			nozzleDiameter = state.Print.NozzleDiameter
+		}
+
+		if err := p.publish(ctx, newCalibrationSelectCommand(tray, id, f, nozzleDiameter)); err != nil {
+			return fmt.Errorf("error selecting calibration for tray %d: %w", id, err)
+		}
+	}
+
+	if err := p.RequestUpdate(ctx); err != nil {
+		return err
+	}
+
+	if err := p.waitState(ctx, func(m *mqtt.Message) bool {
+		t, ok := findTray(m, id)
+		return ok && t.TrayType == f.Type && strings.EqualFold(t.TrayColor, color)
+	}); err != nil {
+		return fmt.Errorf("waiting for tray %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func newFilamentSettingCommand(tray TrayAddress, f FilamentSetting, color string) *protocol.Command {
+	return protocol.NewCommand(protocol.Print).
+		WithCommand("ams_filament_setting").
+		Set("ams_id", tray.AMS).
+		Set("tray_id", tray.Tray).
+		Set("slot_id", tray.Tray).
+		Set("tray_info_idx", f.InfoIdx).
+		Set("tray_type", f.Type).
+		Set("tray_color", color).
+		Set("nozzle_temp_min", f.NozzleTempMin).
+		Set("nozzle_temp_max", f.NozzleTempMax).
+		Set("setting_id", "")
+}
+
+func newCalibrationSelectCommand(tray TrayAddress, id int, f FilamentSetting, nozzleDiameter string) *protocol.Command {
+	return protocol.NewCommand(protocol.Print).
+		WithCommand("extrusion_cali_sel").
+		Set("tray_id", id).
+		Set("ams_id", tray.AMS).
+		Set("slot_id", tray.Tray).
+		Set("cali_idx", *f.CalibrationIndex).
+		Set("filament_id", f.InfoIdx).
+		Set("nozzle_diameter", nozzleDiameter)
+}
+
+// StartDrying publishes an ams_filament_drying command starting a drying cycle on a given AMS unit and waits for the unit to report it.
+// Only AMS 2 Pro and AMS HT units can dry filament, other units return an [ErrDryingNotSupported]. An [ErrInvalidDryingSettings] is returned if s is outside of the units limits.
+func (p *printer) StartDrying(ctx context.Context, amsID int, s DryingSettings) error {
+	ctx, cancel := withDefaultOpTimeout(ctx)
+	defer cancel()
+
+	unit, err := p.dryingUnit(amsID)
+	if err != nil {
+		return err
+	}
+	if err := s.validate(unit.Type); err != nil {
+		return err
+	}
+
+	if err := p.publish(ctx, newDryingCommand(amsID, s, true)); err != nil {
+		return fmt.Errorf("error starting drying on ams %d: %w", amsID, err)
+	}
+
+	return p.waitDrying(ctx, amsID, true)
+}
+
+// StopDrying stops a running drying cycle on a given AMS unit and waits for the unit to report it.
+func (p *printer) StopDrying(ctx context.Context, amsID int) error {
+	ctx, cancel := withDefaultOpTimeout(ctx)
+	defer cancel()
+
+	if _, err := p.dryingUnit(amsID); err != nil {
+		return err
+	}
+
+	if err := p.publish(ctx, newDryingCommand(amsID, DryingSettings{}, false)); err != nil {
+		return fmt.Errorf("error stopping drying on ams %d: %w", amsID, err)
+	}
+
+	return p.waitDrying(ctx, amsID, false)
+}
+
+// dryingUnit finds an AMS unit in the current state and checks that it can dry filament.
+func (p *printer) dryingUnit(amsID int) (AMSUnit, error) {
+	if !models[p.cfg.Model].Capabilities.Has(CapabilityAnyAms) {
+		return AMSUnit{}, ErrAmsNotSupported
+	}
+
+	inv, ok := p.Inventory()
+	if !ok {
+		return AMSUnit{}, ErrStateUnavailable
+	}
+
+	for _, unit := range inv.Units {
+		if unit.ID != amsID {
+			continue
+		}
+		if unit.Type != AMSType2Pro && unit.Type != AMSTypeHT {
+			return AMSUnit{}, fmt.Errorf("%w: ams %d is an %s", ErrDryingNotSupported, amsID, unit.Type)
+		}
+		return unit, nil
+	}
+
+	return AMSUnit{}, fmt.Errorf("%w: ams %d not found", ErrInvalidTray, amsID)
+}
+
+func (p *printer) waitDrying(ctx context.Context, amsID int, active bool) error {
+	if err := p.RequestUpdate(ctx); err != nil {
+		return err
+	}
+
+	if err := p.waitState(ctx, func(m *mqtt.Message) bool {
+		for _, unit := range DecodeAMSInventory(m).Units {
+			if unit.ID == amsID {
+				return unit.Drying.Active == active
+			}
+		}
+		return false
+	}); err != nil {
+		return fmt.Errorf("waiting for drying state on ams %d: %w", amsID, err)
+	}
+
+	return nil
+}
+
+func newDryingCommand(amsID int, s DryingSettings, start bool) *protocol.Command {
+	mode := 0
+	if start {
+		mode = 1
+	}
+
+	return protocol.NewCommand(protocol.Print).
+		WithCommand("ams_filament_drying").
+		Set("ams_id", amsID).
+		Set("mode", mode).
+		Set("temp", s.Temperature).
+		Set("cooling_temp", dryingCoolingTemp).
+		Set("duration", int(s.Duration/time.Hour)).
+		Set("humidity", 0).
+		Set("filament", s.Filament).
+		Set("rotate_tray", false).
+		Set("close_power_conflict", false)
+}
+
+// SetAMSSettings publishes an ams_user_setting command applying s to all AMS units and waits for the printer to report the new settings.
+// If the [Printer] has no AMS, an [ErrAmsNotSupported] will be returned.
+func (p *printer) SetAMSSettings(ctx context.Context, s AMSSettings) error {
+	ctx, cancel := withDefaultOpTimeout(ctx)
+	defer cancel()
+
+	if !models[p.cfg.Model].Capabilities.Has(CapabilityAnyAms) {
+		return ErrAmsNotSupported
+	}
+
+	cmd := protocol.NewCommand(protocol.Print).
+		WithCommand("ams_user_setting").
+		Set("ams_id", -1). // all units
+		Set("startup_read_option", s.StartupRead).
+		Set("tray_read_option", s.ReadOnInsert).
+		Set("calibrate_remain_flag", s.CalibrateRemain)
+
+	if err := p.publish(ctx, cmd); err != nil {
+		return fmt.Errorf("error setting ams settings: %w", err)
+	}
+
+	return p.waitInventory(ctx, func(inv *AMSInventory) bool {
+		return inv.Settings == s
+	})
+}
+
+// SetAutoRefill enables or disables switching to a backup spool of the same filament when the active one runs out and waits for the printer to report the change.
+// If the [Printer] has no AMS, an [ErrAmsNotSupported] will be returned.
+func (p *printer) SetAutoRefill(ctx context.Context, enabled bool) error {
+	ctx, cancel := withDefaultOpTimeout(ctx)
+	defer cancel()
+
+	if !models[p.cfg.Model].Capabilities.Has(CapabilityAnyAms) {
+		return ErrAmsNotSupported
+	}
+
+	cmd := protocol.NewCommand(protocol.Print).
+		WithCommand("print_option").
+		Set("auto_switch_filament", enabled)
+
+	if err := p.publish(ctx, cmd); err != nil {
+		return fmt.Errorf("error setting auto refill: %w", err)
+	}
+
+	return p.waitInventory(ctx, func(inv *AMSInventory) bool {
+		return inv.AutoRefill == enabled
+	})
+}
+
+func (p *printer) waitInventory(ctx context.Context, cond func(*AMSInventory) bool) error {
+	if err := p.RequestUpdate(ctx); err != nil {
+		return err
+	}
+
+	if err := p.waitState(ctx, func(m *mqtt.Message) bool {
+		return cond(DecodeAMSInventory(m))
+	}); err != nil {
+		return fmt.Errorf("waiting for ams settings: %w", err)
+	}
+
+	return nil
+}
+
+// findTray looks up a tray in the state by its printer wide ID.
+func findTray(m *mqtt.Message, id int) (mqtt.Tray, bool) {
+	if id == ExternalTrayID {
+		return m.Print.VtTray, true
+	}
+
+	for _, unit := range m.Print.Ams.Ams {
+		amsID, err := strconv.Atoi(unit.ID)
+		if err != nil {
+			continue
+		}
+		for _, tray := range unit.Tray {
+			trayID, err := strconv.Atoi(tray.ID)
+			if err != nil {
+				continue
+			}
+			if global, err := globalTrayID(amsID, trayID); err == nil && global == id {
+				return tray, true
+			}
+		}
+	}
+
+	return mqtt.Tray{}, false
+}
+
+// end ams
+
+// begin errors
+
+// ClearError acknowledges an error reported by the printer and waits for it to disappear from the state, target is typically the Err of an [EventError] and may wrap the printer error.
+// An [hms.Error] is dismissed with the HMS ignore action and a device error ([hms.PrintError]) with clean_print_error. Any other error returns an [ErrUnknownPrinterError].
+func (p *printer) ClearError(ctx context.Context, target error) error {
+	ctx, cancel := withDefaultOpTimeout(ctx)
+	defer cancel()
+
+	state, ok := p.State()
+	if !ok {
+		return ErrStateUnavailable
+	}
+
+	var cmd *protocol.Command
+	if e, ok := asHMSError(target); ok {
+		cmd = protocol.NewCommand(protocol.Print).
+			WithCommand("ignore").
+			WithParam("reserve").
+			Set("err", hmsActionCode(e)).
+			Set("job_id", state.Print.JobID)
+	} else if code := printErrorCode(target); code != 0 {
+		cmd = protocol.NewCommand(protocol.Print).
+			WithCommand("clean_print_error").
+			Set("subtask_id", state.Print.SubtaskID).
+			Set("print_error", code)
+	} else {
+		return fmt.Errorf("%w: %T", ErrUnknownPrinterError, target)
+	}
+
+	if err := p.publish(ctx, cmd); err != nil {
+		return fmt.Errorf("error clearing %v: %w", target, err)
+	}
+
+	return p.waitErrorGone(ctx, target, nil)
+}
+
+// ResumeAfterError resumes a print paused by target (e.g. a filament runout or a clogged nozzle) once the cause has been resolved and waits for the error to clear and the print to leave the paused state.
+// target must be or wrap an [hms.Error] or [hms.PrintError], any other error returns an [ErrUnknownPrinterError].
+func (p *printer) ResumeAfterError(ctx context.Context, target error) error {
+	ctx, cancel := withDefaultOpTimeout(ctx)
+	defer cancel()
+
+	state, ok := p.State()
+	if !ok {
+		return ErrStateUnavailable
+	}
+
+	var code string
+	if e, ok := asHMSError(target); ok {
+		code = hmsActionCode(e)
+	} else if c := printErrorCode(target); c != 0 {
+		code = fmt.Sprintf("%08X", c)
+	} else {
+		return fmt.Errorf("%w: %T", ErrUnknownPrinterError, target)
+	}
+
+	cmd := protocol.NewCommand(protocol.Print).
+		WithCommand("resume").
+		WithParam("reserve").
+		Set("err", code).
+		Set("job_id", state.Print.JobID)
+
+	if err := p.publish(ctx, cmd); err != nil {
+		return fmt.Errorf("error resuming after %v: %w", target, err)
+	}
+
+	return p.waitErrorGone(ctx, target, func(m *mqtt.Message) bool {
+		return GcodeState(m.Print.GcodeState) != PAUSE
+	})
+}
+
+// waitErrorGone waits until target is no longer reported in the state and the optional cond is satisfied.
+func (p *printer) waitErrorGone(ctx context.Context, target error, cond func(*mqtt.Message) bool) error {
+	return p.waitState(ctx, func(m *mqtt.Message) bool {
+		if cond != nil && !cond(m) {
+			return false
+		}
+
+		if e, ok := asHMSError(target); ok {
+			return !slices.Contains(m.Print.HmsErrors, e)
+		}
+		current := printError(m)
+		return current == nil || current.Code != printErrorCode(target)
+	})
+}
+
+// hmsActionCode formats an HMS error as the 16 digit code expected by the ignore and resume actions.
+func hmsActionCode(e hms.Error) string {
+	return fmt.Sprintf("%08X%08X", e.Attribute, e.Code)
+}
+
+// asHMSError finds the first [hms.Error] in the chain of err.
+func asHMSError(err error) (hms.Error, bool) {
+	var e hms.Error
+	ok := errors.As(err, &e)
+	return e, ok
+}
+
+// printErrorCode returns the raw code of the first device error in the chain of err, passed by value or pointer, or 0 if there is none.
+func printErrorCode(err error) uint32 {
+	var ptr *hms.PrintError
+	if errors.As(err, &ptr) && ptr != nil {
+		return ptr.Code
+	}
+
+	var val hms.PrintError
+	if errors.As(err, &val) {
+		return val.Code
+	}
+	return 0
+}
+
+// end errors
+
 // SendGcode sends raw GCODE commands to the printer via MQTT, be careful of what you send because the commands are currently not validated.
 // EXERCISE CAUTION WHEN USING THIS FUNCTION, IT CAN AND WILL DAMAGE YOUR PRINTER IF USED IMPROPERLY
 func (p *printer) SendGcode(ctx context.Context, input []string) error {
@@ -1,11 +1,16 @@
 package bambulabs_api
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"log/slog"
 	"reflect"
 	"testing"
 	"time"
+
+	"github.com/torbenconto/bambulabs_api/hms"
 )
 
 func TestWithDefaultOpTimeout(t *testing.T) {
@@ -75,3 +80,92 @@ func TestNewLightCommand(t *testing.T) {
 		t.Fatalf("light command = %#v, want %#v", got, want)
 	}
 }
+
+func TestIsStatusReport(t *testing.T) {
+	tests := map[string]bool{
+		`{"print": {"command": "push_status", "gcode_state": "IDLE"}}`:                  true,
+		`{"print": {"gcode_state": "IDLE"}}`:                                            true,
+		`{"print": {"command": "gcode_line", "sequence_id": "1", "result": "success"}}`: false,
+		`{"system": {"command": "ledctrl", "sequence_id": "1", "result": "success"}}`:   false,
+		`{"info": {"command": "get_version"}}`:                                          false,
+		`not json`:                                                                      true,
+	}
+	for payload, want := range tests {
+		if got := isStatusReport([]byte(payload)); got != want {
+			t.Errorf("isStatusReport(%s) = %v, want %v", payload, got, want)
+		}
+	}
+}
+
+func TestUpdateStateMergesDeltas(t *testing.T) {
+	p := newTestPrinter(slog.New(slog.DiscardHandler))
+
+	p.updateState([]byte(`{"print": {"command": "push_status", "msg": 0, "gcode_state": "RUNNING", "layer_num": 1, "print_error": 50348035,
+		"ams": {"tray_now": "1", "ams": [{"id": "0"}]}, "lights_report": [{"node": "chamber_light", "mode": "on"}]}}`))
+	p.updateState([]byte(`{"print": {"command": "push_status", "msg": 1, "layer_num": 2, "ams": {"tray_now": "2"}}}`))
+	p.updateState([]byte(`{"print": {"command": "push_status", "msg": 1, "layer_num": "broken"}}`))
+
+	state, ok := p.State()
+	if !ok {
+		t.Fatal("no state")
+	}
+
+	pr := state.Print
+	if pr.GcodeState != "RUNNING" || pr.LayerNum != 2 || pr.PrintError != 50348035 {
+		t.Errorf("state = %s layer %d error %d, want RUNNING layer 2 error 50348035", pr.GcodeState, pr.LayerNum, pr.PrintError)
+	}
+	if pr.Ams.TrayNow != "2" || len(pr.Ams.Ams) != 1 {
+		t.Errorf("ams = tray_now %q with %d units, want nested fields merged", pr.Ams.TrayNow, len(pr.Ams.Ams))
+	}
+	if len(pr.LightsReport) != 1 {
+		t.Errorf("lights_report = %v, want it kept from the full report", pr.LightsReport)
+	}
+}
+
+// newTestPrinter returns a printer without connections for exercising the state loop directly.
+func newTestPrinter(logger *slog.Logger) *printer {
+	cfg := Config{SerialNumber: "SERIAL", Model: ModelP1S, Logger: logger}
+	return &printer{cfg: cfg, log: cfg.logger(), events: make(chan Event, eventBufferSize)}
+}
+
+func TestUpdateStateLogs(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestPrinter(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	p.updateState([]byte(`{"print": {"command": "push_status", "layer_num": "broken"}}`))
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log output %q: %v", buf.String(), err)
+	}
+	if record["level"] != "WARN" || record["serial"] != "SERIAL" || record["model"] != "P1S" || record["error"] == nil {
+		t.Errorf("log record = %v, want a warning with serial, model and error", record)
+	}
+}
+
+func TestPrinterErrorUnwrap(t *testing.T) {
+	he := hms.Error{Attribute: 0x07008000, Code: 0x00020001}
+	pe := hms.NewPrintError(0x03008004)
+
+	if e, ok := asHMSError(fmt.Errorf("ams: %w", he)); !ok || e != he {
+		t.Errorf("asHMSError(wrapped) = %v, %v, want %v", e, ok, he)
+	}
+	if _, ok := asHMSError(pe); ok {
+		t.Error("asHMSError(print error) = true, want false")
+	}
+
+	tests := map[string]error{
+		"pointer":         pe,
+		"value":           *pe,
+		"wrapped pointer": fmt.Errorf("job paused: %w", pe),
+		"wrapped value":   fmt.Errorf("job paused: %w", *pe),
+	}
+	for name, err := range tests {
+		if got := printErrorCode(err); got != pe.Code {
+			t.Errorf("%s: printErrorCode() = %08X, want %08X", name, got, pe.Code)
+		}
+	}
+	if got := printErrorCode(fmt.Errorf("wrapped: %w", he)); got != 0 {
+		t.Errorf("printErrorCode(hms error) = %08X, want 0", got)
+	}
+}
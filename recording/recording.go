@@ -0,0 +1,158 @@
+// Package recording captures the MQTT traffic between the library and a printer as timestamped JSON lines,
+// for reproducing field issues by replaying them with the emulator.
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Placeholders replacing secrets in recorded topics and payloads, one per kind so replays can substitute their own values.
+const (
+	RedactedSerial     = "REDACTED_SERIAL"
+	RedactedAccessCode = "REDACTED_CODE"
+	RedactedUserID     = "REDACTED_USER"
+	RedactedToken      = "REDACTED_TOKEN"
+)
+
+// Secret is a value redacted from recordings and the placeholder written instead.
+type Secret struct {
+	Value       string
+	Placeholder string
+}
+
+// maxLineSize bounds a single recorded message, full reports of printers with several AMS units are a few tens of KB.
+const maxLineSize = 4 << 20
+
+// Direction tells whether a message was received from or sent to the printer.
+type Direction string
+
+const (
+	// In is a message published by the printer, such as a status report or a command reply.
+	In Direction = "in"
+	// Out is a command published by the library.
+	Out Direction = "out"
+)
+
+// Entry is a single recorded message, one JSON object per line.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Direction Direction `json:"dir"`
+	Topic     string    `json:"topic"`
+	// Payload holds the message if it is valid JSON, otherwise Raw holds the bytes as received.
+	Payload json.RawMessage `json:"payload,omitempty"`
+	Raw     []byte          `json:"raw,omitempty"`
+}
+
+// Bytes returns the message, JSON payloads are compacted when recorded.
+func (e Entry) Bytes() []byte {
+	if e.Payload != nil {
+		return e.Payload
+	}
+	return e.Raw
+}
+
+// Recorder writes entries to an underlying writer, it is safe for concurrent use by several printers.
+type Recorder struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewRecorder returns a recorder writing JSON lines to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w, now: time.Now}
+}
+
+// Record writes a message, redacting the given secrets. Only whole topic segments and whole JSON strings matching a
+// secret are replaced, so values that merely contain a secret, such as a longer number, are kept intact.
+func (r *Recorder) Record(dir Direction, topic string, payload []byte, secrets ...Secret) error {
+	topic, payload = redact(topic, payload, secrets)
+
+	e := Entry{Direction: dir, Topic: topic}
+	if json.Valid(payload) {
+		e.Payload = payload
+	} else {
+		e.Raw = payload
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e.Time = r.now()
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = r.w.Write(append(line, '\n'))
+	return err
+}
+
+// Reader reads entries written by a [Recorder].
+type Reader struct {
+	s *bufio.Scanner
+}
+
+// NewReader returns a reader of the JSON lines in r.
+func NewReader(r io.Reader) *Reader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 64<<10), maxLineSize)
+	return &Reader{s: s}
+}
+
+// Next returns the next entry, or [io.EOF] at the end of the recording. Blank lines are skipped.
+func (r *Reader) Next() (Entry, error) {
+	for r.s.Scan() {
+		line := bytes.TrimSpace(r.s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return Entry{}, err
+		}
+		if e.Direction != In && e.Direction != Out {
+			return Entry{}, errors.New("recording: entry without direction")
+		}
+		return e, nil
+	}
+
+	if err := r.s.Err(); err != nil {
+		return Entry{}, err
+	}
+	return Entry{}, io.EOF
+}
+
+func redact(topic string, payload []byte, secrets []Secret) (string, []byte) {
+	segments := strings.Split(topic, "/")
+	for _, sec := range secrets {
+		if sec.Value == "" {
+			continue
+		}
+
+		for i, seg := range segments {
+			if seg == sec.Value {
+				segments[i] = sec.Placeholder
+			}
+		}
+		// Matching the quoted string works for truncated payloads too, a quote inside a string is always escaped.
+		payload = bytes.ReplaceAll(payload, quote(sec.Value), quote(sec.Placeholder))
+	}
+	return strings.Join(segments, "/"), payload
+}
+
+// quote returns s as a JSON string literal.
+func quote(s string) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
@@ -0,0 +1,115 @@
+package recording
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+var secrets = []Secret{
+	{Value: "01P00A000000001", Placeholder: RedactedSerial},
+	{Value: "12345678", Placeholder: RedactedAccessCode},
+	{Value: "", Placeholder: RedactedToken},
+}
+
+func TestRecordRedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf)
+
+	err := r.Record(In, "device/01P00A000000001/report", []byte(`{"print": {"sn": "01P00A000000001", "code": "12345678"}}`), secrets...)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "01P00A000000001") || strings.Contains(buf.String(), "12345678") {
+		t.Fatalf("recording contains a secret: %s", buf.String())
+	}
+
+	e, err := NewReader(&buf).Next()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if e.Topic != "device/REDACTED_SERIAL/report" {
+		t.Errorf("topic = %q, want device/REDACTED_SERIAL/report", e.Topic)
+	}
+	if want := `{"print":{"sn":"REDACTED_SERIAL","code":"REDACTED_CODE"}}`; string(e.Bytes()) != want {
+		t.Errorf("payload = %s, want %s", e.Bytes(), want)
+	}
+}
+
+func TestRecordKeepsValuesContainingSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf)
+
+	payload := `{"print":{"sequence_id":123456789,"task_id":"112345678","gcode_file":"/data/01P00A000000001.gcode"}}`
+	if err := r.Record(In, "device/01P00A0000000012/report", []byte(payload), secrets...); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	e, err := NewReader(&buf).Next()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if e.Topic != "device/01P00A0000000012/report" {
+		t.Errorf("topic = %q, want it unchanged", e.Topic)
+	}
+	if string(e.Bytes()) != payload {
+		t.Errorf("payload = %s, want %s", e.Bytes(), payload)
+	}
+}
+
+func TestRecordInvalidPayload(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf)
+
+	truncated := []byte(`{"print": {"gcode_state": "RUN`)
+	if err := r.Record(In, "device/x/report", truncated); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	e, err := NewReader(&buf).Next()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if e.Payload != nil || !bytes.Equal(e.Bytes(), truncated) {
+		t.Errorf("entry = payload %s raw %q, want raw %q", e.Payload, e.Raw, truncated)
+	}
+}
+
+func TestReader(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf)
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	r.now = func() time.Time { start = start.Add(time.Second); return start }
+
+	_ = r.Record(Out, "device/x/request", []byte(`{"pushing":{"command":"pushall"}}`))
+	buf.WriteString("\n")
+	_ = r.Record(In, "device/x/report", []byte(`{"print":{"command":"push_status"}}`))
+
+	rd := NewReader(&buf)
+	var got []Entry
+	for {
+		e, err := rd.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		got = append(got, e)
+	}
+
+	if len(got) != 2 || got[0].Direction != Out || got[1].Direction != In {
+		t.Fatalf("entries = %+v, want out then in", got)
+	}
+	if d := got[1].Time.Sub(got[0].Time); d != time.Second {
+		t.Errorf("spacing = %v, want 1s", d)
+	}
+
+	if _, err := NewReader(strings.NewReader(`{"topic":"x"}`)).Next(); err == nil {
+		t.Error("entry without direction accepted")
+	}
+}
@@ -0,0 +1,265 @@
+// Package spool tracks filament spools across printers and prints.
+//
+// Spools are identified by the tray UUID Bambu Lab writes to both RFID tags of a spool, falling back to the tag UID.
+// A [Tracker] records the remaining filament reported by the AMS and the consumption of individual prints, persisting
+// spools through a pluggable [Store]. Spools can be exported in a format accepted by Spoolman.
+package spool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image/color"
+	"sync"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/gcode"
+)
+
+// maxReadings bounds the reading history kept per spool.
+const maxReadings = 1000
+
+var ErrNotFound = errors.New("spool not found")
+
+// Spool is a single tracked filament spool.
+type Spool struct {
+	ID string `json:"id"`
+
+	Type     string  `json:"type"`
+	Color    string  `json:"color"` // RRGGBBAA
+	Diameter float64 `json:"diameter"`
+	Density  float64 `json:"density,omitempty"` // g/cm^3, learned from sliced jobs
+
+	// InitialWeight is the net filament weight of a full spool in grams, 0 if unknown.
+	InitialWeight float64 `json:"initial_weight"`
+	// RemainPercent is the last AMS estimate of the remaining filament, -1 if the AMS has no estimate.
+	RemainPercent int `json:"remain_percent"`
+	// UsedWeight is the sum of all recorded print consumption in grams.
+	UsedWeight float64 `json:"used_weight"`
+
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+
+	Readings []Reading `json:"readings,omitempty"`
+	Usages   []Usage   `json:"usages,omitempty"`
+}
+
+// Reading is a remaining filament estimate reported by the AMS.
+type Reading struct {
+	Time    time.Time                 `json:"time"`
+	Printer string                    `json:"printer"`
+	Tray    bambulabs_api.TrayAddress `json:"tray"`
+	Remain  int                       `json:"remain"`
+}
+
+// Usage is the filament consumed by a single print.
+type Usage struct {
+	Time    time.Time `json:"time"`
+	Printer string    `json:"printer"`
+	Job     string    `json:"job"`
+	Grams   float64   `json:"grams"`
+}
+
+// RemainingWeight returns the remaining filament in grams. The AMS estimate is preferred when available, otherwise the recorded usage is subtracted from the initial weight.
+// It returns 0 if the initial weight of the spool is unknown.
+func (s *Spool) RemainingWeight() float64 {
+	if s.InitialWeight <= 0 {
+		return 0
+	}
+	if s.RemainPercent >= 0 {
+		return s.InitialWeight * float64(s.RemainPercent) / 100
+	}
+	return max(s.InitialWeight-s.UsedWeight, 0)
+}
+
+// Store persists spools, implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the spool with the given ID or an [ErrNotFound].
+	Get(ctx context.Context, id string) (*Spool, error)
+	Put(ctx context.Context, s *Spool) error
+	List(ctx context.Context) ([]*Spool, error)
+}
+
+// Tracker records spool readings and usage into a [Store].
+type Tracker struct {
+	store Store
+	now   func() time.Time
+
+	mu sync.Mutex // serializes read-modify-write cycles on the store
+}
+
+func NewTracker(store Store) *Tracker {
+	return &Tracker{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// SpoolID returns the ID a slot is tracked under, or "" if the spool cannot be identified (e.g. third party spools without RFID).
+func SpoolID(slot bambulabs_api.Slot) string {
+	if !isZeroID(slot.TrayUUID) {
+		return slot.TrayUUID
+	}
+	if !isZeroID(slot.TagUID) {
+		return slot.TagUID
+	}
+	return ""
+}
+
+func isZeroID(id string) bool {
+	for _, c := range id {
+		if c != '0' {
+			return false
+		}
+	}
+	return true
+}
+
+// Observe records the current state of every identifiable spool in inv, a reading is only stored when the remaining estimate changed.
+func (t *Tracker) Observe(ctx context.Context, printer string, inv *bambulabs_api.AMSInventory) error {
+	slots := []bambulabs_api.Slot{inv.External}
+	for _, unit := range inv.Units {
+		slots = append(slots, unit.Slots...)
+	}
+
+	for _, slot := range slots {
+		id := SpoolID(slot)
+		if !slot.Present || id == "" {
+			continue
+		}
+
+		if err := t.update(ctx, id, func(s *Spool) {
+			s.Type = slot.Type
+			s.Color = hexColor(slot.Color)
+			if slot.Diameter > 0 {
+				s.Diameter = slot.Diameter
+			}
+			if slot.Weight > 0 {
+				s.InitialWeight = slot.Weight
+			}
+
+			changed := len(s.Readings) == 0 || s.Readings[len(s.Readings)-1].Remain != slot.Remain
+			s.RemainPercent = slot.Remain
+			if changed {
+				s.Readings = append(s.Readings, Reading{Time: s.LastSeen, Printer: printer, Tray: slot.Address, Remain: slot.Remain})
+				if len(s.Readings) > maxReadings {
+					s.Readings = s.Readings[len(s.Readings)-maxReadings:]
+				}
+			}
+		}); err != nil {
+			return fmt.Errorf("observe spool %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// RecordUsage adds the consumption of a print to a spool.
+func (t *Tracker) RecordUsage(ctx context.Context, printer, job, id string, grams float64) error {
+	return t.update(ctx, id, func(s *Spool) { s.addUsage(printer, job, grams) })
+}
+
+func (s *Spool) addUsage(printer, job string, grams float64) {
+	s.UsedWeight += grams
+	s.Usages = append(s.Usages, Usage{Time: s.LastSeen, Printer: printer, Job: job, Grams: grams})
+}
+
+// RecordPrint records the filament usage of a sliced job against the spools loaded in inv.
+// trays maps each tool (T0, T1, ...) to the tray it printed from, a nil map assumes the default mapping where tool n uses the tray with printer wide ID n.
+// Tools whose tray holds no identifiable spool, or whose weight cannot be derived from the G-code header, are skipped.
+func (t *Tracker) RecordPrint(ctx context.Context, printer, job string, a *gcode.Analysis, inv *bambulabs_api.AMSInventory, trays map[int]bambulabs_api.TrayAddress) error {
+	for tool := range a.Filament {
+		grams := a.FilamentWeight(tool)
+		if grams <= 0 {
+			continue
+		}
+
+		slot, ok := findSlot(inv, tool, trays)
+		if !ok {
+			continue
+		}
+		id := SpoolID(slot)
+		if id == "" {
+			continue
+		}
+
+		density := a.FilamentDensityOf(tool)
+		if err := t.update(ctx, id, func(s *Spool) {
+			s.addUsage(printer, job, grams)
+			if density > 0 {
+				s.Density = density
+			}
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run observes the inventory of p every interval until ctx is done, errors from the store are returned.
+func (t *Tracker) Run(ctx context.Context, p bambulabs_api.Printer, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if inv, ok := p.Inventory(); ok {
+			if err := t.Observe(ctx, p.Serial(), inv); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (t *Tracker) update(ctx context.Context, id string, fn func(s *Spool)) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+
+	s, err := t.store.Get(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		s = &Spool{ID: id, FirstSeen: now, RemainPercent: -1}
+	} else if err != nil {
+		return err
+	}
+
+	s.LastSeen = now
+	fn(s)
+
+	return t.store.Put(ctx, s)
+}
+
+func findSlot(inv *bambulabs_api.AMSInventory, tool int, trays map[int]bambulabs_api.TrayAddress) (bambulabs_api.Slot, bool) {
+	want := bambulabs_api.TrayAddress{AMS: tool / 4, Tray: tool % 4}
+	if trays != nil {
+		addr, ok := trays[tool]
+		if !ok {
+			return bambulabs_api.Slot{}, false
+		}
+		want = addr
+	}
+
+	if want.IsExternal() {
+		return inv.External, true
+	}
+	for _, unit := range inv.Units {
+		for _, slot := range unit.Slots {
+			if slot.Address == want {
+				return slot, true
+			}
+		}
+	}
+	return bambulabs_api.Slot{}, false
+}
+
+func hexColor(c color.NRGBA) string {
+	return fmt.Sprintf("%02X%02X%02X%02X", c.R, c.G, c.B, c.A)
+}
@@ -0,0 +1,229 @@
+package spool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"math"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/gcode"
+)
+
+const uuid = "0123456789ABCDEF0123456789ABCDEF"
+
+func inventory(remain int) *bambulabs_api.AMSInventory {
+	return &bambulabs_api.AMSInventory{
+		Units: []bambulabs_api.AMSUnit{{
+			ID: 0,
+			Slots: []bambulabs_api.Slot{
+				{
+					Address:  bambulabs_api.TrayAddress{AMS: 0, Tray: 0},
+					Present:  true,
+					Type:     "PLA",
+					Color:    color.NRGBA{R: 0xFF, A: 0xFF},
+					Diameter: 1.75,
+					Weight:   1000,
+					Remain:   remain,
+					TrayUUID: uuid,
+				},
+				{Address: bambulabs_api.TrayAddress{AMS: 0, Tray: 1}, Present: true, Type: "PETG", TrayUUID: "00000000000000000000000000000000"},
+			},
+		}},
+		ActiveTray: 0,
+	}
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	tracker := NewTracker(store)
+
+	for _, remain := range []int{90, 90, 85} {
+		if err := tracker.Observe(ctx, "SERIAL", inventory(remain)); err != nil {
+			t.Fatalf("Observe() error = %v", err)
+		}
+	}
+
+	analysis := &gcode.Analysis{
+		Filament:         map[int]float64{0: 1000},
+		FilamentDiameter: []float64{1.75},
+		FilamentDensity:  []float64{1.24},
+	}
+	if err := tracker.RecordPrint(ctx, "SERIAL", "benchy", analysis, inventory(85), nil); err != nil {
+		t.Fatalf("RecordPrint() error = %v", err)
+	}
+
+	spools, err := store.List(ctx)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(spools) != 1 {
+		t.Fatalf("len(spools) = %d, want 1 (spools without an ID are not tracked)", len(spools))
+	}
+
+	s := spools[0]
+	if len(s.Readings) != 2 {
+		t.Errorf("len(Readings) = %d, want 2 (unchanged readings are skipped)", len(s.Readings))
+	}
+	if want := analysis.FilamentWeight(0); math.Abs(s.UsedWeight-want) > 1e-9 || len(s.Usages) != 1 {
+		t.Errorf("UsedWeight = %v with %d usages, want %v with 1", s.UsedWeight, len(s.Usages), want)
+	}
+	if s.RemainingWeight() != 850 {
+		t.Errorf("RemainingWeight() = %v, want 850", s.RemainingWeight())
+	}
+
+	exported := ExportSpoolman(spools)
+	if got := exported[0]; got.Filament.ColorHex != "ff0000" || got.Spool.Extra["tag"] != `"`+uuid+`"` || got.Filament.Density != 1.24 {
+		t.Errorf("ExportSpoolman() = %+v", got)
+	}
+}
+
+// countingStore counts the Puts of a [Store].
+type countingStore struct {
+	Store
+	puts int
+}
+
+func (c *countingStore) Put(ctx context.Context, s *Spool) error {
+	c.puts++
+	return c.Store.Put(ctx, s)
+}
+
+func TestRecordPrintSingleUpdate(t *testing.T) {
+	ctx := context.Background()
+	store := &countingStore{Store: NewMemoryStore()}
+	tracker := NewTracker(store)
+
+	analysis := &gcode.Analysis{
+		Filament:         map[int]float64{0: 1000},
+		FilamentDiameter: []float64{1.75},
+		FilamentDensity:  []float64{1.27},
+	}
+	if err := tracker.RecordPrint(ctx, "SERIAL", "benchy", analysis, inventory(85), nil); err != nil {
+		t.Fatalf("RecordPrint() error = %v", err)
+	}
+	if store.puts != 1 {
+		t.Errorf("RecordPrint() stored the spool %d times, want once", store.puts)
+	}
+
+	s, err := store.Get(ctx, uuid)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if s.Density != 1.27 || len(s.Usages) != 1 {
+		t.Errorf("spool = %+v, want density 1.27 and one usage", s)
+	}
+}
+
+func TestRemainingWeightFallsBackToUsage(t *testing.T) {
+	s := &Spool{InitialWeight: 1000, RemainPercent: -1, UsedWeight: 250}
+	if got := s.RemainingWeight(); got != 750 {
+		t.Errorf("RemainingWeight() = %v, want 750", got)
+	}
+}
+
+func TestExportSpoolmanWeights(t *testing.T) {
+	tests := map[string]struct {
+		spool *Spool
+		want  string
+	}{
+		"ams estimate":   {&Spool{InitialWeight: 1000, RemainPercent: 50, UsedWeight: 100}, `"remaining_weight":500`},
+		"empty spool":    {&Spool{InitialWeight: 1000, RemainPercent: 0}, `"remaining_weight":0`},
+		"usage only":     {&Spool{InitialWeight: 1000, RemainPercent: -1, UsedWeight: 250}, `"used_weight":250`},
+		"unknown weight": {&Spool{RemainPercent: 80, UsedWeight: 40}, `"used_weight":40`},
+		"nothing known":  {&Spool{RemainPercent: -1}, ``},
+	}
+
+	for name, tt := range tests {
+		b, err := json.Marshal(ExportSpoolman([]*Spool{tt.spool})[0].Spool)
+		if err != nil {
+			t.Fatalf("%s: Marshal() error = %v", name, err)
+		}
+
+		got := string(b)
+		var weights []string
+		for _, field := range []string{`"remaining_weight"`, `"used_weight"`} {
+			if strings.Contains(got, field) {
+				weights = append(weights, field)
+			}
+		}
+		if len(weights) > 1 || (tt.want == "") != (len(weights) == 0) || !strings.Contains(got, tt.want) {
+			t.Errorf("%s: spool = %s, want only %s", name, got, tt.want)
+		}
+		if !strings.Contains(got, `"filament_id":`) || strings.Contains(got, `"filament":`) {
+			t.Errorf("%s: spool = %s, want a filament_id reference", name, got)
+		}
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spools.json")
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	if err := store.Put(ctx, &Spool{ID: uuid, Type: "PLA", LastSeen: time.Unix(100, 0).UTC()}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() reopen error = %v", err)
+	}
+	s, err := reopened.Get(ctx, uuid)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if s.Type != "PLA" || !s.LastSeen.Equal(time.Unix(100, 0)) {
+		t.Errorf("Get() = %+v", s)
+	}
+}
+
+func TestFileStoreConcurrentPut(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "spools.json")
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Go(func() {
+			if err := store.Put(ctx, &Spool{ID: fmt.Sprintf("%032d", i)}); err != nil {
+				t.Errorf("Put() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore() reopen error = %v", err)
+	}
+	if spools, _ := reopened.List(ctx); len(spools) != 20 {
+		t.Errorf("file holds %d spools, want 20", len(spools))
+	}
+}
+
+func TestSpoolmanExportJSON(t *testing.T) {
+	b, err := json.Marshal(ExportSpoolman([]*Spool{{ID: uuid, Type: "PLA", Color: "FF0000FF"}})[0])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, field := range []string{`"filament":{`, `"spool":{`, `"color_hex":"ff0000"`, `"filament_id":0`} {
+		if !strings.Contains(string(b), field) {
+			t.Errorf("export = %s, want %s", b, field)
+		}
+	}
+}
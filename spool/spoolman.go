@@ -0,0 +1,110 @@
+package spool
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// defaultDensity is used for materials without a known density, Spoolman requires one for every filament.
+const defaultDensity = 1.24
+
+var materialDensity = map[string]float64{
+	"PLA":  1.24,
+	"PETG": 1.27,
+	"ABS":  1.04,
+	"ASA":  1.07,
+	"TPU":  1.21,
+	"PA":   1.14,
+	"PC":   1.20,
+	"PVA":  1.23,
+}
+
+// SpoolmanExport is a single spool in Spoolman's format. Spoolman stores filaments and spools separately, so importing takes two requests:
+// POST Filament to /api/v1/filament, then set Spool.FilamentID to the ID of the created filament and POST Spool to /api/v1/spool.
+type SpoolmanExport struct {
+	Filament SpoolmanFilament `json:"filament"`
+	Spool    SpoolmanSpool    `json:"spool"`
+}
+
+// SpoolmanSpool matches the body of Spoolman's POST /api/v1/spool endpoint.
+// At most one of RemainingWeight and UsedWeight is set, Spoolman rejects spools with both.
+type SpoolmanSpool struct {
+	FilamentID      int               `json:"filament_id"`
+	InitialWeight   float64           `json:"initial_weight,omitempty"`
+	RemainingWeight *float64          `json:"remaining_weight,omitempty"`
+	UsedWeight      *float64          `json:"used_weight,omitempty"`
+	FirstUsed       *time.Time        `json:"first_used,omitempty"`
+	LastUsed        *time.Time        `json:"last_used,omitempty"`
+	Extra           map[string]string `json:"extra,omitempty"`
+}
+
+// SpoolmanFilament matches the body of Spoolman's POST /api/v1/filament endpoint.
+type SpoolmanFilament struct {
+	Name     string  `json:"name"`
+	Material string  `json:"material"`
+	ColorHex string  `json:"color_hex,omitempty"`
+	Density  float64 `json:"density"`
+	Diameter float64 `json:"diameter"`
+	Weight   float64 `json:"weight,omitempty"`
+}
+
+// ExportSpoolman converts spools into Spoolman's format, see [SpoolmanExport] for how to import them. The spool ID is stored in the "tag" extra field, which Spoolman expects to hold JSON encoded values.
+func ExportSpoolman(spools []*Spool) []SpoolmanExport {
+	out := make([]SpoolmanExport, 0, len(spools))
+
+	for _, s := range spools {
+		tag, _ := json.Marshal(s.ID)
+
+		density := s.Density
+		if density <= 0 {
+			density = densityOf(s.Type)
+		}
+
+		exported := SpoolmanExport{
+			Filament: SpoolmanFilament{
+				Name:     s.Type,
+				Material: s.Type,
+				ColorHex: strings.ToLower(s.Color[:min(len(s.Color), 6)]),
+				Density:  density,
+				Diameter: s.Diameter,
+				Weight:   s.InitialWeight,
+			},
+			Spool: SpoolmanSpool{
+				InitialWeight: s.InitialWeight,
+				Extra:         map[string]string{"tag": string(tag)},
+			},
+		}
+
+		// Prefer the AMS estimate, otherwise report the recorded consumption and let Spoolman derive the rest.
+		switch {
+		case s.InitialWeight > 0 && s.RemainPercent >= 0:
+			remaining := s.RemainingWeight()
+			exported.Spool.RemainingWeight = &remaining
+		case s.UsedWeight > 0:
+			used := s.UsedWeight
+			exported.Spool.UsedWeight = &used
+		}
+
+		if !s.FirstSeen.IsZero() {
+			exported.Spool.FirstUsed = &s.FirstSeen
+		}
+		if !s.LastSeen.IsZero() {
+			exported.Spool.LastUsed = &s.LastSeen
+		}
+
+		out = append(out, exported)
+	}
+
+	return out
+}
+
+func densityOf(material string) float64 {
+	// Variants such as "PLA-CF" or "PETG HF" share the density of their base material closely enough.
+	base, _, _ := strings.Cut(strings.ToUpper(material), "-")
+	base, _, _ = strings.Cut(base, " ")
+	if d, ok := materialDensity[base]; ok {
+		return d
+	}
+	return defaultDensity
+}
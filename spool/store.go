@@ -0,0 +1,134 @@
+package spool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryStore is an in-memory [Store], useful for tests and short-lived processes.
+type MemoryStore struct {
+	mu     sync.Mutex
+	spools map[string]*Spool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{spools: make(map[string]*Spool)}
+}
+
+func (m *MemoryStore) Get(_ context.Context, id string) (*Spool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.spools[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return clone(s), nil
+}
+
+func (m *MemoryStore) Put(_ context.Context, s *Spool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.spools[s.ID] = clone(s)
+	return nil
+}
+
+func (m *MemoryStore) List(_ context.Context) ([]*Spool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	spools := make([]*Spool, 0, len(m.spools))
+	for _, s := range m.spools {
+		spools = append(spools, clone(s))
+	}
+	slices.SortFunc(spools, func(a, b *Spool) int { return strings.Compare(a.ID, b.ID) })
+	return spools, nil
+}
+
+// FileStore is a [Store] persisting all spools to a single JSON file, the file is rewritten atomically on every Put.
+type FileStore struct {
+	path string
+	mem  *MemoryStore
+
+	mu sync.Mutex // held from updating mem until the snapshot is renamed, so an older snapshot never replaces a newer one
+}
+
+// NewFileStore loads spools from path, a missing file starts an empty store.
+func NewFileStore(path string) (*FileStore, error) {
+	f := &FileStore{path: path, mem: NewMemoryStore()}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var spools []*Spool
+	if err := json.Unmarshal(data, &spools); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	for _, s := range spools {
+		f.mem.spools[s.ID] = s
+	}
+
+	return f, nil
+}
+
+func (f *FileStore) Get(ctx context.Context, id string) (*Spool, error) {
+	return f.mem.Get(ctx, id)
+}
+
+func (f *FileStore) List(ctx context.Context) ([]*Spool, error) {
+	return f.mem.List(ctx)
+}
+
+func (f *FileStore) Put(ctx context.Context, s *Spool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.mem.Put(ctx, s); err != nil {
+		return err
+	}
+
+	spools, err := f.mem.List(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(spools, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func clone(s *Spool) *Spool {
+	c := *s
+	c.Readings = slices.Clone(s.Readings)
+	c.Usages = slices.Clone(s.Usages)
+	return &c
+}
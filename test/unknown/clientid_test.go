@@ -0,0 +1,45 @@
+package x1_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+)
+
+func TestConcurrentClients(t *testing.T) {
+	e, _ := faultyPrinter(t)
+
+	// a second process connecting to the same printer must not kick the first one off
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+
+	cfg := e.PrinterConfig()
+	cfg.ClientIDPrefix = "dashboard"
+	p, err := c.Add(cfg)
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	ids := e.ClientIDs()
+	if len(ids) != 2 || ids[0] == ids[1] {
+		t.Fatalf("client ids = %v, want two distinct", ids)
+	}
+	if !strings.HasPrefix(ids[0], "bambulabs_api-") || !strings.HasPrefix(ids[1], "dashboard-") {
+		t.Errorf("client ids = %v, want the default and the configured prefix", ids)
+	}
+
+	timeout := time.After(1500 * time.Millisecond)
+	for {
+		select {
+		case ev := <-p.Events():
+			if ev.Type == bambulabs_api.EventDisconnected {
+				t.Fatalf("second client disconnected: %v", ev.Err)
+			}
+		case <-timeout:
+			return
+		}
+	}
+}
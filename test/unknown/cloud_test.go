@@ -0,0 +1,83 @@
+package x1_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// cloudConfig returns a config reaching e as if it were the cloud broker.
+func cloudConfig(e *emulator.Emulator, token string) bambulabs_api.Config {
+	cfg := e.PrinterConfig()
+	cfg.Host, cfg.MQTTPort, cfg.AccessCode = nil, 0, ""
+	cfg.Cloud = &bambulabs_api.CloudConfig{
+		Broker: net.JoinHostPort("127.0.0.1", strconv.Itoa(e.Port())),
+		UserID: "1234567",
+		Token:  token,
+	}
+	cfg.TLS.RootCAs = e.RootCAs()
+	return cfg
+}
+
+func TestCloud(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{
+		Model:        bambulabs_api.ModelP1S,
+		SerialNumber: "BBLCLOUD0001",
+		Credentials:  map[string]string{"u_1234567": "cloud-token"},
+	})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+
+	p, err := c.Add(cloudConfig(e, "cloud-token"))
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+	waitFor(t, p, "report over the cloud", func(m *mqtt.Message) bool { return len(m.Print.Ams.Ams) > 0 })
+
+	if err := p.SetLight(context.Background(), bambulabs_api.ChamberLight, bambulabs_api.LightOn); err != nil {
+		t.Errorf("set light: %v", err)
+	}
+	if _, err := p.ListFiles("/"); !errors.Is(err, bambulabs_api.ErrFTPUnavailable) {
+		t.Errorf("list files = %v, want %v", err, bambulabs_api.ErrFTPUnavailable)
+	}
+}
+
+func TestCloudRejected(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{
+		Model:       bambulabs_api.ModelP1S,
+		Credentials: map[string]string{"u_1234567": "cloud-token"},
+	})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+
+	if _, err := c.Add(cloudConfig(e, "expired-token")); err == nil {
+		t.Error("connected with a wrong token")
+	}
+
+	untrusted := cloudConfig(e, "cloud-token")
+	untrusted.TLS.RootCAs = nil
+	if _, err := c.Add(untrusted); err == nil {
+		t.Error("connected to a broker with an untrusted certificate")
+	}
+
+	incomplete := cloudConfig(e, "")
+	if _, err := c.Add(incomplete); !errors.Is(err, bambulabs_api.ErrInvalidCloudConfig) {
+		t.Errorf("add printer without token = %v, want %v", err, bambulabs_api.ErrInvalidCloudConfig)
+	}
+}
@@ -0,0 +1,176 @@
+package x1_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// waitFor polls the printer state until cond is satisfied.
+func waitFor(t *testing.T, p bambulabs_api.Printer, what string, cond func(*mqtt.Message) bool) {
+	t.Helper()
+
+	deadline := time.After(3 * time.Second)
+	for {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for %s", what)
+		case <-time.After(20 * time.Millisecond):
+			if state, ok := p.State(); ok && cond(state) {
+				return
+			}
+		}
+	}
+}
+
+func TestSetLight(t *testing.T) {
+	_, p := client(t)
+	ctx := context.Background()
+
+	if err := p.SetLight(ctx, bambulabs_api.ChamberLight, bambulabs_api.LightOn); err != nil {
+		t.Fatalf("set light: %v", err)
+	}
+
+	waitFor(t, p, "chamber light on", func(m *mqtt.Message) bool {
+		for _, l := range m.Print.LightsReport {
+			if l.Node == string(bambulabs_api.ChamberLight) {
+				return l.Mode == string(bambulabs_api.LightOn)
+			}
+		}
+		return false
+	})
+}
+
+func TestSetFan(t *testing.T) {
+	_, p := client(t)
+
+	if err := p.SetFan(context.Background(), bambulabs_api.PartCoolingFan, 255); err != nil {
+		t.Fatalf("set fan: %v", err)
+	}
+
+	waitFor(t, p, "part cooling fan at full speed", func(m *mqtt.Message) bool {
+		return m.Print.CoolingFanSpeed == "15"
+	})
+}
+
+func TestSendGcodeTemperatures(t *testing.T) {
+	_, p := client(t)
+
+	if err := p.SendGcode(context.Background(), []string{"M104 S215", "M140 S60"}); err != nil {
+		t.Fatalf("send gcode: %v", err)
+	}
+
+	waitFor(t, p, "target temperatures", func(m *mqtt.Message) bool {
+		return m.Print.NozzleTargetTemper == 215 && m.Print.BedTargetTemper == 60
+	})
+}
+
+func TestChangeFilament(t *testing.T) {
+	_, p := client(t)
+	ctx := context.Background()
+
+	if err := p.LoadFilament(ctx, 0, 2); err != nil {
+		t.Fatalf("load filament: %v", err)
+	}
+	if state, _ := p.State(); state.Print.Ams.TrayNow != "2" || state.Print.Ams.TrayTar != "2" {
+		t.Errorf("after load tray_now = %q, tray_tar = %q, want 2", state.Print.Ams.TrayNow, state.Print.Ams.TrayTar)
+	}
+
+	if err := p.UnloadFilament(ctx); err != nil {
+		t.Fatalf("unload filament: %v", err)
+	}
+	if state, _ := p.State(); state.Print.Ams.TrayNow != "255" {
+		t.Errorf("after unload tray_now = %q, want 255", state.Print.Ams.TrayNow)
+	}
+}
+
+func TestResumeAfterError(t *testing.T) {
+	wraps := map[string]func(error) error{
+		"direct":  func(err error) error { return err },
+		"wrapped": func(err error) error { return fmt.Errorf("job paused: %w", err) },
+	}
+
+	for name, wrap := range wraps {
+		t.Run(name, func(t *testing.T) {
+			_, p := client(t)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			runout := &emulator.Scenario{Seed: 1, Steps: []emulator.Step{
+				{State: bambulabs_api.PREPARE, TotalLayers: 10},
+				{State: bambulabs_api.RUNNING, Layers: 3},
+				{State: bambulabs_api.PAUSE, PrintError: "0300-8004"},
+			}}
+			if err := emu.Play(ctx, runout); err != nil {
+				t.Fatalf("play: %v", err)
+			}
+
+			waitFor(t, p, "runout pause", func(m *mqtt.Message) bool {
+				return m.Print.GcodeState == string(bambulabs_api.PAUSE)
+			})
+
+			pe, ok := p.PrintError()
+			if !ok {
+				t.Fatal("no print error reported")
+			}
+			if err := p.ResumeAfterError(ctx, wrap(pe)); err != nil {
+				t.Fatalf("resume after error: %v", err)
+			}
+
+			state, _ := p.State()
+			if state.Print.GcodeState != string(bambulabs_api.RUNNING) || state.Print.LayerNum != 3 {
+				t.Errorf("after resume = %s at layer %d, want RUNNING at layer 3", state.Print.GcodeState, state.Print.LayerNum)
+			}
+			if _, ok := p.PrintError(); ok {
+				t.Error("print error still reported after resume")
+			}
+		})
+	}
+}
+
+func TestRecordedCommands(t *testing.T) {
+	_, p := client(t)
+	emu.ClearCommands()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if err := p.SetLight(ctx, bambulabs_api.ChamberLight, bambulabs_api.LightOff); err != nil {
+		t.Fatalf("set light: %v", err)
+	}
+
+	cmd, err := emu.WaitCommand(ctx, "ledctrl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var payload struct {
+		LedNode string `json:"led_node"`
+		LedMode string `json:"led_mode"`
+	}
+	if err := json.Unmarshal(cmd.Payload, &payload); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if cmd.Type != "system" || payload.LedNode != "chamber_light" || payload.LedMode != "off" {
+		t.Errorf("recorded %s %s, want system ledctrl chamber_light off", cmd.Type, cmd.Payload)
+	}
+}
+
+func TestPublishPrint(t *testing.T) {
+	_, p := client(t)
+
+	if err := emu.PublishPrint(map[string]any{"wifi_signal": "-42dBm"}); err != nil {
+		t.Fatalf("publish print: %v", err)
+	}
+
+	waitFor(t, p, "custom wifi signal", func(m *mqtt.Message) bool {
+		return m.Print.WifiSignal == "-42dBm"
+	})
+}
@@ -0,0 +1,82 @@
+package x1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+)
+
+// waitEvent returns the next event of type want, skipping others.
+func waitEvent(t *testing.T, p bambulabs_api.Printer, want bambulabs_api.EventType) bambulabs_api.Event {
+	t.Helper()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for %v event", want)
+		case ev := <-p.Events():
+			if ev.Type == want {
+				return ev
+			}
+		}
+	}
+}
+
+func TestReconnectEvents(t *testing.T) {
+	e, p := faultyPrinter(t)
+	e.ClearCommands()
+
+	e.DropClients()
+
+	if ev := waitEvent(t, p, bambulabs_api.EventDisconnected); ev.Err == nil {
+		t.Error("disconnected event without a cause")
+	}
+	waitEvent(t, p, bambulabs_api.EventReconnecting)
+	waitEvent(t, p, bambulabs_api.EventConnected)
+
+	status := p.ConnectionStatus()
+	if status.State != bambulabs_api.Connected || status.LastError == nil {
+		t.Errorf("status = %+v, want connected with the last error kept", status)
+	}
+
+	// delta reports missed while offline are recovered with a pushall
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if _, err := e.WaitCommand(ctx, "pushall"); err != nil {
+		t.Errorf("no pushall after reconnecting: %v", err)
+	}
+}
+
+func TestStaleState(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelX1C, SerialNumber: "BBLSTALE0001"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+
+	cfg := e.PrinterConfig()
+	cfg.StaleAfter = 300 * time.Millisecond
+	p, err := c.Add(cfg)
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	e.SetFaults(emulator.Faults{DropPublishes: true})
+	waitEvent(t, p, bambulabs_api.EventStale)
+	if !p.ConnectionStatus().Stale {
+		t.Error("status not stale")
+	}
+
+	e.SetFaults(emulator.Faults{})
+	waitEvent(t, p, bambulabs_api.EventStaleCleared)
+	if status := p.ConnectionStatus(); status.Stale || status.State != bambulabs_api.Connected {
+		t.Errorf("status = %+v, want connected and fresh", status)
+	}
+}
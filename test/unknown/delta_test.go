@@ -0,0 +1,44 @@
+package x1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+func TestDeltaReportsKeepState(t *testing.T) {
+	p1, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelP1S, SerialNumber: "BBLP1S0001"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer p1.Stop()
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+	p, err := c.Add(p1.PrinterConfig())
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	if err := p.RequestUpdate(context.Background()); err != nil {
+		t.Fatalf("request update: %v", err)
+	}
+	waitFor(t, p, "full report", func(m *mqtt.Message) bool {
+		return len(m.Print.Ams.Ams) > 0
+	})
+
+	if err := p.SendGcode(context.Background(), []string{"M140 S70"}); err != nil {
+		t.Fatalf("send gcode: %v", err)
+	}
+	waitFor(t, p, "bed target from a delta report", func(m *mqtt.Message) bool {
+		return m.Print.BedTargetTemper == 70
+	})
+
+	state, _ := p.State()
+	if state.Print.Msg != 1 || len(state.Print.Ams.Ams) == 0 || len(state.Print.LightsReport) == 0 {
+		t.Errorf("state after delta = msg %d, %d ams units, %d lights, want fields of the full report kept", state.Print.Msg, len(state.Print.Ams.Ams), len(state.Print.LightsReport))
+	}
+}
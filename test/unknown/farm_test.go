@@ -0,0 +1,71 @@
+package x1_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+func TestFarm(t *testing.T) {
+	const printers = 12
+
+	farm, err := emulator.StartFarm(context.Background(), emulator.FarmConfigs(printers,
+		bambulabs_api.ModelX1C, bambulabs_api.ModelP1S, bambulabs_api.ModelA1, bambulabs_api.ModelH2D)...)
+	if err != nil {
+		t.Fatalf("start farm: %v", err)
+	}
+	defer farm.Stop()
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, printers)
+	for _, cfg := range farm.Configs() {
+		wg.Go(func() {
+			if _, err := c.Add(cfg); err != nil {
+				errs <- err
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	n := 0
+	c.Range(func(bambulabs_api.Printer) bool {
+		n++
+		return true
+	})
+	if n != printers {
+		t.Fatalf("client ranges over %d printers, want %d", n, printers)
+	}
+
+	// drive a single printer and check the others are unaffected
+	target := farm.Emulators()[5]
+	if err := target.PublishPrint(map[string]any{"mc_percent": 77}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	p, err := c.Load(target.PrinterConfig().SerialNumber)
+	if err != nil {
+		t.Fatalf("load printer: %v", err)
+	}
+	waitFor(t, p, "progress of the driven printer", func(m *mqtt.Message) bool { return m.Print.McPercent == 77 })
+
+	c.Range(func(other bambulabs_api.Printer) bool {
+		if other.Serial() == p.Serial() {
+			return true
+		}
+		if state, ok := other.State(); ok && state.Print.McPercent == 77 {
+			t.Errorf("%s received the report of %s", other.Serial(), p.Serial())
+		}
+		return true
+	})
+}
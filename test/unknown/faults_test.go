@@ -0,0 +1,133 @@
+package x1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+)
+
+// faultyPrinter starts a dedicated emulator so injected faults do not leak into other tests, and connects a client to it.
+func faultyPrinter(t *testing.T) (*emulator.Emulator, bambulabs_api.Printer) {
+	t.Helper()
+
+	e, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelX1C, SerialNumber: "BBLFAULT0001"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	t.Cleanup(e.Stop)
+
+	c := bambulabs_api.NewClient(context.Background())
+	t.Cleanup(func() { c.Close() })
+	p, err := c.Add(e.PrinterConfig())
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	waitFor(t, p, "first report", func(*mqtt.Message) bool { return true })
+	return e, p
+}
+
+func TestRejectedAuth(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelX1C})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+
+	wrong := e.PrinterConfig()
+	wrong.AccessCode = "00000000"
+	if _, err := c.Add(wrong); err == nil {
+		t.Error("connected with a wrong access code")
+	}
+
+	e.SetFaults(emulator.Faults{RejectAuth: true})
+	if _, err := c.Add(e.PrinterConfig()); err == nil {
+		t.Error("connected while auth is rejected")
+	}
+
+	e.SetFaults(emulator.Faults{})
+	if _, err := c.Add(e.PrinterConfig()); err != nil {
+		t.Errorf("connect after clearing faults: %v", err)
+	}
+}
+
+func TestReconnectAfterDrop(t *testing.T) {
+	e, p := faultyPrinter(t)
+
+	if n := e.DropClients(); n != 1 {
+		t.Fatalf("dropped %d clients, want 1", n)
+	}
+
+	// the client resubscribes on reconnect, so changes published afterwards arrive again
+	deadline := time.After(5 * time.Second)
+	for {
+		if err := e.PublishPrint(map[string]any{"mc_percent": 42}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+		if state, ok := p.State(); ok && state.Print.McPercent == 42 {
+			break
+		}
+
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for a report after reconnecting")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func TestMalformedReports(t *testing.T) {
+	e, p := faultyPrinter(t)
+
+	if err := e.PublishPrint(map[string]any{"mc_percent": 10}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	waitFor(t, p, "progress 10", func(m *mqtt.Message) bool { return m.Print.McPercent == 10 })
+
+	e.SetFaults(emulator.Faults{DropPublishes: true})
+	if err := e.PublishPrint(map[string]any{"mc_percent": 30}); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	e.SetFaults(emulator.Faults{})
+
+	if err := e.PublishTruncated(); err != nil {
+		t.Fatalf("publish truncated: %v", err)
+	}
+	e.PublishInvalidJSON()
+	if err := e.PublishOversized(2 << 20); err != nil {
+		t.Fatalf("publish oversized: %v", err)
+	}
+	e.Publish([]byte(`{"print": {"command": "push_status", "mc_percent": 20}}`))
+
+	waitFor(t, p, "progress 20", func(m *mqtt.Message) bool { return m.Print.McPercent == 20 })
+	if state, _ := p.State(); state.Print.GcodeState == "" {
+		t.Error("malformed reports cleared the state")
+	}
+}
+
+func TestDelayedPublishes(t *testing.T) {
+	e, p := faultyPrinter(t)
+
+	const delay = 300 * time.Millisecond
+	e.SetFaults(emulator.Faults{PublishDelay: delay})
+	defer e.SetFaults(emulator.Faults{})
+
+	start := time.Now()
+	for _, percent := range []int{50, 60} {
+		if err := e.PublishPrint(map[string]any{"mc_percent": percent}); err != nil {
+			t.Fatalf("publish: %v", err)
+		}
+	}
+
+	waitFor(t, p, "delayed progress", func(m *mqtt.Message) bool { return m.Print.McPercent == 60 })
+	if elapsed := time.Since(start); elapsed < delay {
+		t.Errorf("report arrived after %v, want at least %v", elapsed, delay)
+	}
+}
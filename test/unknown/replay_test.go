@@ -0,0 +1,90 @@
+package x1_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+	"github.com/torbenconto/bambulabs_api/internal/mqtt"
+	"github.com/torbenconto/bambulabs_api/recording"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the concurrent writes of the MQTT client and reads of the test.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	src, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelP1S, SerialNumber: "BBLREC0001"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer src.Stop()
+
+	var rec lockedBuffer
+	cfg := src.PrinterConfig()
+	cfg.Recorder = recording.NewRecorder(&rec)
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+	p, err := c.Add(cfg)
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	if err := p.RequestUpdate(context.Background()); err != nil {
+		t.Fatalf("request update: %v", err)
+	}
+	if err := p.SendGcode(context.Background(), []string{"M140 S70"}); err != nil {
+		t.Fatalf("send gcode: %v", err)
+	}
+	waitFor(t, p, "bed target", func(m *mqtt.Message) bool {
+		return m.Print.BedTargetTemper == 70 && len(m.Print.Ams.Ams) > 0
+	})
+
+	recorded := rec.String()
+	if strings.Contains(recorded, cfg.SerialNumber) || strings.Contains(recorded, cfg.AccessCode) {
+		t.Fatal("recording contains the serial number or access code")
+	}
+	if !strings.Contains(recorded, `"dir":"out"`) || !strings.Contains(recorded, "M140 S70") {
+		t.Fatal("recording is missing the sent commands")
+	}
+
+	dst, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelP1S, SerialNumber: "BBLREP0001", Seed: 7})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer dst.Stop()
+
+	replayed, err := c.Add(dst.PrinterConfig())
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	// let the report answering the pushall sent on connect arrive first, it would overwrite the replayed state
+	waitFor(t, replayed, "report of the replaying emulator", func(*mqtt.Message) bool { return true })
+
+	if err := dst.Replay(context.Background(), strings.NewReader(recorded), 0); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	waitFor(t, replayed, "replayed bed target", func(m *mqtt.Message) bool {
+		return m.Print.BedTargetTemper == 70 && len(m.Print.Ams.Ams) > 0
+	})
+}
@@ -0,0 +1,51 @@
+package x1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+)
+
+func TestScenario(t *testing.T) {
+	_, p := client(t)
+
+	s, err := emulator.LoadScenario("../../emulator/testdata/runout.yaml")
+	if err != nil {
+		t.Fatalf("load scenario: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	played := make(chan error, 1)
+	go func() { played <- emu.Play(ctx, s) }()
+
+	var sawError, sawFinish bool
+	for !sawFinish {
+		select {
+		case ev := <-p.Events():
+			if ev.Type == bambulabs_api.EventError {
+				sawError = true
+			}
+		case <-time.After(20 * time.Millisecond):
+			if state, ok := p.State(); ok && state.Print.GcodeState == string(bambulabs_api.FINISH) {
+				sawFinish = true
+				if state.Print.LayerNum != 10 || state.Print.McPercent != 100 {
+					t.Errorf("finished at layer %d (%d%%), want 10 (100%%)", state.Print.LayerNum, state.Print.McPercent)
+				}
+			}
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the scenario to finish")
+		}
+	}
+
+	if err := <-played; err != nil {
+		t.Errorf("play: %v", err)
+	}
+	if !sawError {
+		t.Error("no error event for the injected runout")
+	}
+}
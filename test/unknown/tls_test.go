@@ -0,0 +1,125 @@
+package x1_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/torbenconto/bambulabs_api"
+	"github.com/torbenconto/bambulabs_api/emulator"
+)
+
+func TestPinnedCertificate(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelX1C, SerialNumber: "BBLTLS0001"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+
+	var mu sync.Mutex
+	var pins []string
+	cfg := e.PrinterConfig()
+	cfg.TLS.OnPin = func(serial, fingerprint string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		pins = append(pins, fingerprint)
+		return nil
+	}
+
+	p, err := c.Add(cfg)
+	if err != nil {
+		t.Fatalf("add printer: %v", err)
+	}
+
+	// reconnects are checked against the pinned certificate without pinning again
+	e.DropClients()
+	waitEvent(t, p, bambulabs_api.EventConnected)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want := bambulabs_api.Fingerprint(e.Certificate()); len(pins) != 1 || pins[0] != want {
+		t.Errorf("pinned %v, want once %s", pins, want)
+	}
+}
+
+func TestCertificateRejected(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelX1C, SerialNumber: "BBLTLS0002"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	other, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelX1C, SerialNumber: "BBLTLS0003"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer other.Stop()
+
+	tests := map[string]bambulabs_api.TLSConfig{
+		"fingerprint mismatch": {Fingerprint: bambulabs_api.Fingerprint(other.Certificate())},
+		"unknown ca":           {Mode: bambulabs_api.TLSVerify, RootCAs: other.RootCAs()},
+	}
+	for name, tlsCfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := bambulabs_api.NewClient(context.Background())
+			defer c.Close()
+
+			cfg := e.PrinterConfig()
+			cfg.TLS = tlsCfg
+			if _, err := c.Add(cfg); !errors.Is(err, bambulabs_api.ErrCertificateRejected) {
+				t.Errorf("add printer = %v, want %v", err, bambulabs_api.ErrCertificateRejected)
+			}
+		})
+	}
+
+	t.Run("serial mismatch", func(t *testing.T) {
+		c := bambulabs_api.NewClient(context.Background())
+		defer c.Close()
+
+		// the certificate is valid but issued to another printer
+		cfg := other.PrinterConfig()
+		cfg.MQTTPort = e.Port()
+		cfg.TLS = bambulabs_api.TLSConfig{Mode: bambulabs_api.TLSVerify, RootCAs: e.RootCAs()}
+		if _, err := c.Add(cfg); !errors.Is(err, bambulabs_api.ErrCertificateRejected) {
+			t.Errorf("add printer = %v, want %v", err, bambulabs_api.ErrCertificateRejected)
+		}
+	})
+}
+
+func TestVerifiedCertificate(t *testing.T) {
+	e, err := emulator.Start(context.Background(), emulator.Config{Model: bambulabs_api.ModelX1C, SerialNumber: "BBLTLS0004"})
+	if err != nil {
+		t.Fatalf("start emulator: %v", err)
+	}
+	defer e.Stop()
+
+	for _, tlsCfg := range []bambulabs_api.TLSConfig{
+		{Mode: bambulabs_api.TLSVerify, RootCAs: e.RootCAs()},
+		{Fingerprint: bambulabs_api.Fingerprint(e.Certificate())},
+		{Mode: bambulabs_api.TLSInsecure},
+	} {
+		t.Run(tlsCfg.Mode.String(), func(t *testing.T) {
+			c := bambulabs_api.NewClient(context.Background())
+			defer c.Close()
+
+			cfg := e.PrinterConfig()
+			cfg.TLS = tlsCfg
+			if _, err := c.Add(cfg); err != nil {
+				t.Errorf("add printer: %v", err)
+			}
+		})
+	}
+
+	c := bambulabs_api.NewClient(context.Background())
+	defer c.Close()
+	cfg := e.PrinterConfig()
+	cfg.TLS.Mode = bambulabs_api.TLSVerify
+	// without RootCAs the certs/ bundle is used, it is empty or holds a CA that did not sign the emulator's certificate
+	if _, err := c.Add(cfg); !errors.Is(err, bambulabs_api.ErrCertificateRejected) && !errors.Is(err, bambulabs_api.ErrInvalidTLSConfig) {
+		t.Errorf("add printer without RootCAs = %v, want %v or %v", err, bambulabs_api.ErrCertificateRejected, bambulabs_api.ErrInvalidTLSConfig)
+	}
+}
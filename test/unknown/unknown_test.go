@@ -2,33 +2,31 @@ package x1_test
 
 import (
 	"context"
-	"net"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/torbenconto/bambulabs_api"
-	"github.com/torbenconto/bambulabs_api/internal/emulator"
+	"github.com/torbenconto/bambulabs_api/emulator"
 )
 
 var (
-	cfg = bambulabs_api.Config{
-		Host:         net.ParseIP("127.0.0.1"),
-		MQTTPort:     mqttPort,
-		Model:        bambulabs_api.ModelX1C,
-		AccessCode:   "test1234",
-		SerialNumber: "BBLX1C0001",
-	}
-	emu      *emulator.Emulator
-	mqttPort = 18883
+	cfg bambulabs_api.Config
+	emu *emulator.Emulator
 )
 
 func TestMain(m *testing.M) {
 	var err error
-	emu, err = emulator.Start(context.Background(), &cfg, mqttPort)
+	emu, err = emulator.Start(context.Background(), emulator.Config{
+		Model:        bambulabs_api.ModelX1C,
+		AccessCode:   "test1234",
+		SerialNumber: "BBLX1C0001",
+	})
 	if err != nil {
 		panic("start emulator: " + err.Error())
 	}
+	cfg = emu.PrinterConfig()
+
 	code := m.Run()
 	emu.Stop()
 	os.Exit(code)
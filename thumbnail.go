@@ -0,0 +1,208 @@
+package bambulabs_api
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/torbenconto/bambulabs_api/internal/threemf"
+)
+
+// thumbnailCacheSize bounds the number of thumbnails kept per printer, a farm dashboard only needs the current job.
+const thumbnailCacheSize = 16
+
+// Directories searched for the project file of the current job, Bambu Studio uploads "send to printer" jobs to /cache.
+var jobDirectories = []string{"/", "/cache"}
+
+var plateGcodePattern = regexp.MustCompile(`plate_(\d+)\.gcode$`)
+
+type thumbnailKey struct {
+	path    string
+	modTime time.Time
+	plate   int
+}
+
+// thumbnailCache is a small FIFO cache, thumbnails are keyed by modtime so a re-uploaded file is fetched again.
+type thumbnailCache struct {
+	mu      sync.Mutex
+	entries map[thumbnailKey][]byte
+	order   []thumbnailKey
+}
+
+func (c *thumbnailCache) get(key thumbnailKey) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	png, ok := c.entries[key]
+	return png, ok
+}
+
+func (c *thumbnailCache) put(key thumbnailKey, png []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[thumbnailKey][]byte)
+	}
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+
+	if len(c.order) >= thumbnailCacheSize {
+		delete(c.entries, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.entries[key] = png
+	c.order = append(c.order, key)
+}
+
+// Thumbnail downloads a .3mf project from the printer and returns the PNG preview of the given 1-based plate.
+// Results are cached by file path and modification time. Returns an [ErrThumbnailNotFound] if the file or plate preview does not exist and [ErrFTPUnavailable] if FTP is unavailable.
+func (p *printer) Thumbnail(name string, plate int) ([]byte, error) {
+	if p.ftp == nil {
+		return nil, ErrFTPUnavailable
+	}
+	if !strings.HasSuffix(name, ".3mf") {
+		return nil, fmt.Errorf("%w: %s is not a 3mf project", ErrThumbnailNotFound, name)
+	}
+
+	info, err := p.stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.thumbnail(name, info, plate)
+}
+
+// CurrentThumbnail returns the plate preview for the job currently reported by the printer, resolving the project file on storage from the gcode_file and subtask_name state fields.
+// Returns an [ErrStateUnavailable] if no state has been received yet and an [ErrThumbnailNotFound] if the job has no project file with a preview (e.g. plain G-code jobs).
+func (p *printer) CurrentThumbnail() ([]byte, error) {
+	if p.ftp == nil {
+		return nil, ErrFTPUnavailable
+	}
+
+	state, ok := p.State()
+	if !ok {
+		return nil, ErrStateUnavailable
+	}
+
+	name, info, err := p.resolveJobFile(state.Print.GcodeFile, state.Print.SubtaskName)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.thumbnail(name, info, plateFromGcodeFile(state.Print.GcodeFile))
+}
+
+func (p *printer) thumbnail(name string, info os.FileInfo, plate int) ([]byte, error) {
+	key := thumbnailKey{path: name, modTime: info.ModTime(), plate: plate}
+	if png, ok := p.thumbnails.get(key); ok {
+		return png, nil
+	}
+
+	var buf bytes.Buffer
+	if err := p.ftp.Retrieve(name, &buf); err != nil {
+		return nil, fmt.Errorf("download %s: %w", name, err)
+	}
+
+	png, err := plateThumbnail(name, buf.Bytes(), plate)
+	if err != nil {
+		return nil, err
+	}
+
+	p.thumbnails.put(key, png)
+	return png, nil
+}
+
+// plateThumbnail extracts the preview of a plate from the contents of a project file, files that are not .3mf archives
+// such as plain G-code uploads have no preview.
+func plateThumbnail(name string, data []byte, plate int) ([]byte, error) {
+	archive, err := threemf.Open(bytes.NewReader(data), int64(len(data)))
+	if errors.Is(err, zip.ErrFormat) {
+		return nil, fmt.Errorf("%w: %s is not a 3mf project", ErrThumbnailNotFound, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	png, err := archive.PlateThumbnail(plate)
+	if errors.Is(err, threemf.ErrPlateNotFound) {
+		return nil, fmt.Errorf("%w: plate %d of %s", ErrThumbnailNotFound, plate, name)
+	}
+	return png, err
+}
+
+// stat finds a single file by listing its parent directory, the printers FTP server has no reliable MLST/SIZE support.
+func (p *printer) stat(name string) (os.FileInfo, error) {
+	entries, err := p.ftp.List(path.Dir(name))
+	if err != nil {
+		return nil, err
+	}
+
+	base := path.Base(name)
+	for _, e := range entries {
+		if e.Name() == base && !e.IsDir() {
+			return e, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrThumbnailNotFound, name)
+}
+
+func (p *printer) resolveJobFile(gcodeFile, subtaskName string) (string, os.FileInfo, error) {
+	// Jobs started from a project file on storage report it directly.
+	if strings.HasSuffix(gcodeFile, ".3mf") {
+		name := path.Join("/", gcodeFile)
+		info, err := p.stat(name)
+		return name, info, err
+	}
+
+	if subtaskName == "" {
+		return "", nil, fmt.Errorf("%w: no job reported", ErrThumbnailNotFound)
+	}
+
+	for _, dir := range jobDirectories {
+		entries, err := p.ftp.List(dir)
+		if err != nil {
+			continue // /cache does not exist on every model
+		}
+
+		for _, e := range entries {
+			if !e.IsDir() && matchesJob(e.Name(), subtaskName) {
+				return path.Join(dir, e.Name()), e, nil
+			}
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w: no project file for %q", ErrThumbnailNotFound, subtaskName)
+}
+
+// matchesJob reports whether a file on storage is the project file of a job with the given subtask name,
+// the printer reports the name without the .3mf or .gcode.3mf extension.
+func matchesJob(file, subtaskName string) bool {
+	return file == subtaskName+".gcode.3mf" ||
+		file == subtaskName+".3mf" ||
+		(file == subtaskName && strings.HasSuffix(file, ".3mf"))
+}
+
+// plateFromGcodeFile extracts the plate index from gcode_file values such as "/data/Metadata/plate_2.gcode", defaulting to the first plate.
+func plateFromGcodeFile(gcodeFile string) int {
+	m := plateGcodePattern.FindStringSubmatch(gcodeFile)
+	if m == nil {
+		return 1
+	}
+
+	n, err := strconv.Atoi(m[1])
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
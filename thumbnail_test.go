@@ -0,0 +1,49 @@
+package bambulabs_api
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlateFromGcodeFile(t *testing.T) {
+	tests := map[string]int{
+		"/data/Metadata/plate_2.gcode": 2,
+		"Metadata/plate_11.gcode":      11,
+		"benchy.gcode":                 1,
+		"":                             1,
+	}
+
+	for in, want := range tests {
+		if got := plateFromGcodeFile(in); got != want {
+			t.Errorf("plateFromGcodeFile(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestMatchesJob(t *testing.T) {
+	tests := []struct {
+		file    string
+		subtask string
+		want    bool
+	}{
+		{"benchy.gcode.3mf", "benchy", true},
+		{"benchy.3mf", "benchy", true},
+		{"benchy.3mf", "benchy.3mf", true},
+		{"benchy.gcode", "benchy", false},
+		{"benchy2.3mf", "benchy", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchesJob(tt.file, tt.subtask); got != tt.want {
+			t.Errorf("matchesJob(%q, %q) = %v, want %v", tt.file, tt.subtask, got, tt.want)
+		}
+	}
+}
+
+func TestPlateThumbnailNotProject(t *testing.T) {
+	gcode := []byte("; generated by test\nG28\nG1 X10 Y10 E1\n")
+
+	if _, err := plateThumbnail("/cache/benchy.gcode", gcode, 1); !errors.Is(err, ErrThumbnailNotFound) {
+		t.Errorf("plateThumbnail() error = %v, want %v", err, ErrThumbnailNotFound)
+	}
+}
@@ -0,0 +1,176 @@
+package bambulabs_api
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+)
+
+// bundledCerts holds the certificate authorities shipped with the library, see certs/README.md.
+//
+//go:embed certs
+var bundledCerts embed.FS
+
+// bundledRootCAs returns the pool of bundled certificate authorities, nil if none are bundled.
+var bundledRootCAs = sync.OnceValue(func() *x509.CertPool {
+	certs, _ := fs.Sub(bundledCerts, "certs") // only fails for invalid paths
+	pool, err := loadRootCAs(certs)
+	if err != nil {
+		panic(fmt.Sprintf("bambulabs_api: bundled certificates: %v", err))
+	}
+	return pool
+})
+
+// TLSMode selects how the certificate of a printer is checked.
+type TLSMode uint8
+
+const (
+	// TLSPinned trusts the certificate seen on the first connection and requires every later connection to present the same one.
+	// It is the default since printers use certificates that standard verification rejects.
+	TLSPinned TLSMode = iota
+	// TLSVerify verifies the certificate chain against [TLSConfig.RootCAs] and requires the serial number as common name.
+	// No CA ships with the library yet, RootCAs is required unless a PEM file has been added to certs/.
+	TLSVerify
+	// TLSInsecure accepts any certificate, leaving the connection open to interception.
+	TLSInsecure
+)
+
+func (m TLSMode) String() string {
+	switch m {
+	case TLSPinned:
+		return "Pinned"
+	case TLSVerify:
+		return "Verify"
+	case TLSInsecure:
+		return "Insecure"
+	default:
+		return "Unknown"
+	}
+}
+
+// TLSConfig configures how the MQTT and FTP connections to a printer are secured, the zero value pins the certificate on first use.
+type TLSConfig struct {
+	Mode TLSMode
+
+	// Fingerprint is the hex encoded SHA-256 fingerprint of the printers certificate for [TLSPinned], as passed to OnPin.
+	// If empty the first certificate seen is pinned for the lifetime of the printer.
+	Fingerprint string
+	// OnPin is called when a certificate is pinned on first use so the fingerprint can be persisted and passed as Fingerprint next time.
+	// Returning an error rejects the connection.
+	OnPin func(serial, fingerprint string) error
+
+	// RootCAs overrides the certificate authorities the printers certificates are checked against for [TLSVerify], nil uses the CAs embedded from certs/, which holds none yet.
+	RootCAs *x509.CertPool
+}
+
+// Fingerprint returns the hex encoded SHA-256 fingerprint of a certificate, the format of [TLSConfig.Fingerprint].
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// certVerifier checks the certificates of one printer, it is shared by the MQTT and FTP connections and their reconnects.
+type certVerifier struct {
+	cfg    TLSConfig
+	serial string
+	roots  *x509.CertPool
+
+	mu     sync.Mutex
+	pinned string
+}
+
+// clientTLS returns the [tls.Config] securing the connections to the printer with the given serial number.
+func (t TLSConfig) clientTLS(serial string) (*tls.Config, error) {
+	roots := t.RootCAs
+	switch t.Mode {
+	case TLSInsecure:
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	case TLSVerify:
+		if roots == nil {
+			roots = bundledRootCAs()
+		}
+		if roots == nil {
+			return nil, fmt.Errorf("%w: %s mode requires RootCAs, no CA is bundled", ErrInvalidTLSConfig, t.Mode)
+		}
+	case TLSPinned:
+	default:
+		return nil, fmt.Errorf("%w: unknown mode %d", ErrInvalidTLSConfig, t.Mode)
+	}
+
+	v := &certVerifier{cfg: t, serial: serial, roots: roots, pinned: strings.ToLower(t.Fingerprint)}
+	return &tls.Config{
+		// Printer certificates name the serial number rather than the host, standard hostname verification is replaced by VerifyConnection.
+		InsecureSkipVerify: true,
+		VerifyConnection:   v.verify,
+	}, nil
+}
+
+func (v *certVerifier) verify(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("%w: no certificate presented", ErrCertificateRejected)
+	}
+	leaf := cs.PeerCertificates[0]
+
+	if v.cfg.Mode == TLSVerify {
+		intermediates := x509.NewCertPool()
+		for _, c := range cs.PeerCertificates[1:] {
+			intermediates.AddCert(c)
+		}
+		if _, err := leaf.Verify(x509.VerifyOptions{Roots: v.roots, Intermediates: intermediates}); err != nil {
+			return fmt.Errorf("%w: %v", ErrCertificateRejected, err)
+		}
+		if leaf.Subject.CommonName != v.serial {
+			return fmt.Errorf("%w: issued to %q, want serial %s", ErrCertificateRejected, leaf.Subject.CommonName, v.serial)
+		}
+		return nil
+	}
+
+	fingerprint := Fingerprint(leaf)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.pinned != "" {
+		if fingerprint != v.pinned {
+			return fmt.Errorf("%w: fingerprint %s, pinned %s", ErrCertificateRejected, fingerprint, v.pinned)
+		}
+		return nil
+	}
+
+	if v.cfg.OnPin != nil {
+		if err := v.cfg.OnPin(v.serial, fingerprint); err != nil {
+			return fmt.Errorf("%w: pin rejected: %v", ErrCertificateRejected, err)
+		}
+	}
+	v.pinned = fingerprint
+	return nil
+}
+
+// loadRootCAs builds a pool from the PEM files in the root of fsys, returning nil if there are none.
+func loadRootCAs(fsys fs.FS) (*x509.CertPool, error) {
+	names, err := fs.Glob(fsys, "*.pem")
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	pool := x509.NewCertPool()
+	for _, name := range names {
+		pem, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no certificates", name)
+		}
+	}
+	return pool, nil
+}
@@ -0,0 +1,118 @@
+package bambulabs_api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// testChain issues a CA and a leaf certificate for serial signed by it, as printers present them.
+func testChain(t *testing.T, serial string) (ca, leaf *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ca, err = x509.ParseCertificate(der); err != nil {
+		t.Fatal(err)
+	}
+
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: serial},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err = x509.CreateCertificate(rand.Reader, leafTmpl, ca, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if leaf, err = x509.ParseCertificate(der); err != nil {
+		t.Fatal(err)
+	}
+	return ca, leaf
+}
+
+// withBundledRootCAs replaces the bundled CA pool for the duration of the test.
+func withBundledRootCAs(t *testing.T, pool *x509.CertPool) {
+	t.Helper()
+
+	prev := bundledRootCAs
+	bundledRootCAs = func() *x509.CertPool { return pool }
+	t.Cleanup(func() { bundledRootCAs = prev })
+}
+
+func TestBundledRootCAs(t *testing.T) {
+	// the shipped bundle must parse, it is empty until Bambu Lab's CA is added to certs/
+	_ = bundledRootCAs()
+
+	ca, leaf := testChain(t, "01S00A000000001")
+	pool, err := loadRootCAs(fstest.MapFS{
+		"bambulab.pem": {Data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw})},
+		"README.md":    {Data: []byte("not a certificate")},
+	})
+	if err != nil || pool == nil {
+		t.Fatalf("loadRootCAs() = %v, %v", pool, err)
+	}
+	withBundledRootCAs(t, pool)
+
+	cfg, err := TLSConfig{Mode: TLSVerify}.clientTLS("01S00A000000001")
+	if err != nil {
+		t.Fatalf("clientTLS() error = %v", err)
+	}
+	if err := cfg.VerifyConnection(tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}); err != nil {
+		t.Errorf("VerifyConnection() error = %v, want the bundled CA to be trusted", err)
+	}
+
+	// RootCAs overrides the bundle
+	other, _ := testChain(t, "01S00A000000001")
+	roots := x509.NewCertPool()
+	roots.AddCert(other)
+	cfg, err = TLSConfig{Mode: TLSVerify, RootCAs: roots}.clientTLS("01S00A000000001")
+	if err != nil {
+		t.Fatalf("clientTLS() error = %v", err)
+	}
+	if err := cfg.VerifyConnection(tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf}}); !errors.Is(err, ErrCertificateRejected) {
+		t.Errorf("VerifyConnection() error = %v, want %v", err, ErrCertificateRejected)
+	}
+}
+
+func TestLoadRootCAs(t *testing.T) {
+	if pool, err := loadRootCAs(fstest.MapFS{"README.md": {}}); pool != nil || err != nil {
+		t.Errorf("loadRootCAs(no pem) = %v, %v, want nil, nil", pool, err)
+	}
+	if _, err := loadRootCAs(fstest.MapFS{"broken.pem": {Data: []byte("garbage")}}); err == nil {
+		t.Error("loadRootCAs(broken pem) error = nil")
+	}
+
+	withBundledRootCAs(t, nil)
+	if _, err := (TLSConfig{Mode: TLSVerify}).clientTLS("01S00A000000001"); !errors.Is(err, ErrInvalidTLSConfig) {
+		t.Errorf("clientTLS() without bundle = %v, want %v", err, ErrInvalidTLSConfig)
+	}
+}